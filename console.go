@@ -0,0 +1,202 @@
+package wdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//
+// TYPES
+//
+
+// ConsoleLevel is a level of a console entry, it matches the name of the console method.
+type ConsoleLevel string
+
+const (
+	ConsoleDebug ConsoleLevel = "debug"
+	ConsoleLog   ConsoleLevel = "log"
+	ConsoleInfo  ConsoleLevel = "info"
+	ConsoleWarn  ConsoleLevel = "warn"
+	ConsoleError ConsoleLevel = "error"
+)
+
+// ConsoleSource is a source of a console entry.
+type ConsoleSource string
+
+const (
+	// ConsoleSourceConsole is a call of a console method.
+	ConsoleSourceConsole ConsoleSource = "console"
+	// ConsoleSourceException is an uncaught JavaScript error.
+	ConsoleSourceException ConsoleSource = "exception"
+	// ConsoleSourceRejection is an unhandled promise rejection.
+	ConsoleSourceRejection ConsoleSource = "unhandledrejection"
+)
+
+// ConsoleEntry is a message logged or thrown by the page.
+type ConsoleEntry struct {
+	Level     ConsoleLevel
+	Message   string
+	Source    ConsoleSource
+	Timestamp time.Time
+}
+
+// Uncaught reports whether entry e is an uncaught error or an unhandled rejection.
+func (e ConsoleEntry) Uncaught() bool {
+	return e.Source == ConsoleSourceException || e.Source == ConsoleSourceRejection
+}
+
+type consoleEntry struct {
+	Level     ConsoleLevel  `json:"level"`
+	Message   string        `json:"message"`
+	Source    ConsoleSource `json:"source"`
+	Timestamp int64         `json:"timestamp"`
+}
+
+// consoleScript wraps console methods and listens to uncaught errors once per document, buffering the entries in the page.
+const consoleScript = `if (window.__wdcConsole) return;
+var b = window.__wdcConsole = [];
+var push = function(level, message, source) {
+	b.push({level: level, message: message, source: source, timestamp: Date.now()});
+	if (b.length > 1000) b.shift();
+};
+var format = function(v) {
+	if (v instanceof Error) return v.stack || String(v);
+	if (typeof v === 'string') return v;
+	try { return JSON.stringify(v); } catch (e) { return String(v); }
+};
+['debug', 'log', 'info', 'warn', 'error'].forEach(function(level) {
+	var orig = console[level];
+	console[level] = function() {
+		push(level, Array.prototype.map.call(arguments, format).join(' '), 'console');
+		if (orig) return orig.apply(console, arguments);
+	};
+});
+window.addEventListener('error', function(e) {
+	var m = e.error ? format(e.error) : e.message;
+	push('error', e.filename ? m + ' (' + e.filename + ':' + e.lineno + ':' + e.colno + ')' : m, 'exception');
+});
+window.addEventListener('unhandledrejection', function(e) {
+	push('error', format(e.reason), 'unhandledrejection');
+});`
+
+//
+// METHODS
+//
+
+// ConsoleCapture enables capturing of console messages, uncaught errors and unhandled rejections of the current page.
+//
+// The hooks are injected into the current document and injected again after NavigateTo, NavigateBack, NavigateForward and PageRefresh.
+// Messages logged before the injection, e.g. during the page load, are not captured.
+// A failed injection after navigation doesn't fail the navigation, it's reported by ConsoleEntries and ConsoleCheckErrors
+// until the hooks are injected again by ConsoleCapture or the next navigation.
+func (c *Client) ConsoleCapture(ctx context.Context) error {
+	c.mu.Lock()
+	c.console = true
+	c.mu.Unlock()
+
+	return c.consoleInject(ctx)
+}
+
+// ConsoleCaptureStop disables injection of the console hooks after navigation. Hooks already injected into the current document stay active.
+func (c *Client) ConsoleCaptureStop() {
+	c.mu.Lock()
+	c.console = false
+	c.consoleErr = nil
+	c.mu.Unlock()
+}
+
+// ConsoleEntries returns console entries buffered by the current page in order they were logged.
+func (c *Client) ConsoleEntries(ctx context.Context) ([]ConsoleEntry, error) {
+	c.mu.Lock()
+	ierr := c.consoleErr
+	c.mu.Unlock()
+
+	if ierr != nil {
+		return nil, fmt.Errorf("console hooks are not injected: %w", ierr)
+	}
+
+	res, err := ExecuteScript[[]consoleEntry](ctx, c, "return window.__wdcConsole || []")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]ConsoleEntry, len(res))
+	for i, e := range res {
+		entries[i] = ConsoleEntry{
+			Level:     e.Level,
+			Message:   e.Message,
+			Source:    e.Source,
+			Timestamp: time.UnixMilli(e.Timestamp),
+		}
+	}
+
+	return entries, nil
+}
+
+// ConsoleClear removes console entries buffered by the current page.
+func (c *Client) ConsoleClear(ctx context.Context) error {
+	_, err := c.PageScript(ctx, "if (window.__wdcConsole) window.__wdcConsole.length = 0", nil)
+	return err
+}
+
+// ConsoleCheckErrors returns an error wrapping ErrorJavaScriptError if the current page has thrown any uncaught error or unhandled rejection.
+func (c *Client) ConsoleCheckErrors(ctx context.Context) error {
+	entries, err := c.ConsoleEntries(ctx)
+	if err != nil {
+		return err
+	}
+
+	var msgs []string
+	for _, e := range entries {
+		if e.Uncaught() {
+			msgs = append(msgs, e.Message)
+		}
+	}
+
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %d uncaught: %s", ErrorJavaScriptError, len(msgs), strings.Join(msgs, "; "))
+}
+
+// consoleInject injects the console hooks into the current document and records the result for ConsoleEntries.
+func (c *Client) consoleInject(ctx context.Context) error {
+	_, err := c.PageScript(ctx, consoleScript, nil)
+
+	c.mu.Lock()
+	c.consoleErr = err
+	c.mu.Unlock()
+
+	return err
+}
+
+// navigated updates the client state after a navigation command completed with error err and returns err.
+//
+// The state is updated on success and on errors after which the page may have navigated anyway, i.e. a page load timeout or an alert opened during the load.
+// The frame path is reset. Console hooks and network instrumentation are injected if enabled. Injection failures are not returned,
+// so the navigation command itself never fails because of them. A failed console injection is recorded by consoleInject,
+// the network instrumentation is injected again by WaitForNetworkIdle anyway.
+func (c *Client) navigated(ctx context.Context, err error) error {
+	if err != nil && !errors.Is(err, ErrorTimeout) && !errors.Is(err, ErrorUnexpectedAlertOpen) {
+		return err
+	}
+
+	c.setFrames(nil)
+
+	c.mu.Lock()
+	console, network := c.console, c.network
+	c.mu.Unlock()
+
+	if console {
+		_ = c.consoleInject(ctx)
+	}
+	if network {
+		_ = c.networkInject(ctx)
+	}
+
+	return err
+}
@@ -7,20 +7,149 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"time"
 )
 
 //
 // TYPES
 //
 
+// Cookie represents a cookie of the current browsing context.
+//
+// https://www.w3.org/TR/webdriver/#cookies
 type Cookie struct {
-	Name     string `json:"name"`
-	Value    string `json:"value"`
-	Path     string `json:"path"`
-	Domain   string `json:"domain"`
-	Secure   bool   `json:"secure"`
-	HTTPOnly bool   `json:"httpOnly"`
-	Expiry   int    `json:"expiry"`
+	Name     string
+	Value    string
+	Path     string
+	Domain   string
+	Secure   bool
+	HTTPOnly bool
+	// Expiry is the time the cookie expires at. The zero value means a session cookie.
+	Expiry   time.Time
+	SameSite SameSite
+}
+
+// SameSite is a value of the cookie SameSite attribute.
+type SameSite string
+
+const (
+	SameSiteLax    SameSite = "Lax"
+	SameSiteStrict SameSite = "Strict"
+	SameSiteNone   SameSite = "None"
+)
+
+// cookieJSON is a serialized form of a cookie, empty optional fields are omitted.
+type cookieJSON struct {
+	Name     string   `json:"name"`
+	Value    string   `json:"value"`
+	Path     string   `json:"path,omitempty"`
+	Domain   string   `json:"domain,omitempty"`
+	Secure   bool     `json:"secure"`
+	HTTPOnly bool     `json:"httpOnly"`
+	Expiry   *float64 `json:"expiry,omitempty"`
+	SameSite SameSite `json:"sameSite,omitempty"`
+}
+
+// MarshalJSON encodes cookie v with expiry in seconds since the Unix epoch. Session cookies have no expiry.
+func (v Cookie) MarshalJSON() ([]byte, error) {
+	j := cookieJSON{
+		Name:     v.Name,
+		Value:    v.Value,
+		Path:     v.Path,
+		Domain:   v.Domain,
+		Secure:   v.Secure,
+		HTTPOnly: v.HTTPOnly,
+		SameSite: v.SameSite,
+	}
+
+	if !v.Expiry.IsZero() {
+		e := float64(v.Expiry.Unix())
+		j.Expiry = &e
+	}
+
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON decodes cookie v. Missing expiry leaves it zero, i.e. a session cookie. Fractional expiry is truncated to seconds.
+func (v *Cookie) UnmarshalJSON(bytes []byte) error {
+	j := cookieJSON{}
+
+	err := json.Unmarshal(bytes, &j)
+	if err != nil {
+		return err
+	}
+
+	*v = Cookie{
+		Name:     j.Name,
+		Value:    j.Value,
+		Path:     j.Path,
+		Domain:   j.Domain,
+		Secure:   j.Secure,
+		HTTPOnly: j.HTTPOnly,
+		SameSite: j.SameSite,
+	}
+
+	if j.Expiry != nil {
+		v.Expiry = time.Unix(int64(*j.Expiry), 0)
+	}
+
+	return nil
+}
+
+// HTTPCookie converts cookie v to a net/http cookie.
+func (v Cookie) HTTPCookie() *http.Cookie {
+	hc := &http.Cookie{
+		Name:     v.Name,
+		Value:    v.Value,
+		Path:     v.Path,
+		Domain:   v.Domain,
+		Expires:  v.Expiry,
+		Secure:   v.Secure,
+		HttpOnly: v.HTTPOnly,
+	}
+
+	switch v.SameSite {
+	case SameSiteLax:
+		hc.SameSite = http.SameSiteLaxMode
+	case SameSiteStrict:
+		hc.SameSite = http.SameSiteStrictMode
+	case SameSiteNone:
+		hc.SameSite = http.SameSiteNoneMode
+	}
+
+	return hc
+}
+
+// CookieFromHTTP converts a net/http cookie hc to a cookie. Max-Age takes precedence over Expires as in browsers.
+func CookieFromHTTP(hc *http.Cookie) Cookie {
+	v := Cookie{
+		Name:     hc.Name,
+		Value:    hc.Value,
+		Path:     hc.Path,
+		Domain:   hc.Domain,
+		Expiry:   hc.Expires,
+		Secure:   hc.Secure,
+		HTTPOnly: hc.HttpOnly,
+	}
+
+	switch {
+	case hc.MaxAge > 0:
+		v.Expiry = time.Now().Add(time.Duration(hc.MaxAge) * time.Second)
+	case hc.MaxAge < 0:
+		v.Expiry = time.Unix(1, 0)
+	}
+
+	switch hc.SameSite {
+	case http.SameSiteLaxMode:
+		v.SameSite = SameSiteLax
+	case http.SameSiteStrictMode:
+		v.SameSite = SameSiteStrict
+	case http.SameSiteNoneMode:
+		v.SameSite = SameSiteNone
+	}
+
+	return v
 }
 
 //
@@ -55,7 +184,7 @@ func (c *Client) Cookie(ctx context.Context, n string) (Cookie, error) {
 		return Cookie{}, errors.New("cookie name is empty")
 	}
 
-	route := fmt.Sprintf("session/%s/cookie/%s", c.session.ID, n)
+	route := fmt.Sprintf("session/%s/cookie/%s", c.session.ID, url.PathEscape(n))
 
 	req, err := c.prepare(http.MethodGet, route, nil)
 	if err != nil {
@@ -74,23 +203,12 @@ func (c *Client) Cookie(ctx context.Context, n string) (Cookie, error) {
 
 // CookieSet command is used to set a cookie v.
 //
+// Only the name is required. Empty path and domain are omitted, so the server defaults them to the current page.
 // https://www.w3.org/TR/webdriver/#add-cookie
 func (c *Client) CookieSet(ctx context.Context, v Cookie) error {
 	if v.Name == "" {
 		return errors.New("cookie name field is empty")
 	}
-	if v.Value == "" {
-		return errors.New("cookie value field is empty")
-	}
-	if v.Path == "" {
-		return errors.New("cookie path field is empty")
-	}
-	if v.Domain == "" {
-		return errors.New("cookie domain field is empty")
-	}
-	if v.Expiry == 0 {
-		return errors.New("cookie expiry field is empty")
-	}
 
 	r := &cookieRequest{Cookie: v}
 
@@ -125,7 +243,7 @@ func (c *Client) CookieDelete(ctx context.Context, n string) error {
 		return errors.New("cookie name is empty")
 	}
 
-	route := fmt.Sprintf("session/%s/cookie/%s", c.session.ID, n)
+	route := fmt.Sprintf("session/%s/cookie/%s", c.session.ID, url.PathEscape(n))
 
 	req, err := c.prepare(http.MethodDelete, route, nil)
 	if err != nil {
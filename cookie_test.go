@@ -0,0 +1,186 @@
+package wdc
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCookieMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Cookie
+		want string
+	}{
+		{
+			name: "session cookie",
+			v:    Cookie{Name: "a", Value: "1"},
+			want: `{"name":"a","value":"1","secure":false,"httpOnly":false}`,
+		},
+		{
+			name: "all fields",
+			v: Cookie{
+				Name:     "a",
+				Value:    "1",
+				Path:     "/app",
+				Domain:   ".example.com",
+				Secure:   true,
+				HTTPOnly: true,
+				Expiry:   time.Unix(1893456000, 500),
+				SameSite: SameSiteStrict,
+			},
+			want: `{"name":"a","value":"1","path":"/app","domain":".example.com","secure":true,"httpOnly":true,"expiry":1893456000,"sameSite":"Strict"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("got %s, want %s", b, tt.want)
+			}
+		})
+	}
+}
+
+func TestCookieUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want Cookie
+		err  bool
+	}{
+		{
+			name: "missing expiry",
+			data: `{"name":"a","value":"1","path":"/","domain":"example.com","secure":false,"httpOnly":false}`,
+			want: Cookie{Name: "a", Value: "1", Path: "/", Domain: "example.com"},
+		},
+		{
+			name: "null expiry",
+			data: `{"name":"a","value":"1","expiry":null}`,
+			want: Cookie{Name: "a", Value: "1"},
+		},
+		{
+			name: "integer expiry",
+			data: `{"name":"a","value":"1","expiry":1893456000,"sameSite":"Lax","secure":true,"httpOnly":true}`,
+			want: Cookie{Name: "a", Value: "1", Expiry: time.Unix(1893456000, 0), SameSite: SameSiteLax, Secure: true, HTTPOnly: true},
+		},
+		{
+			name: "fractional expiry",
+			data: `{"name":"a","value":"1","expiry":1893456000.75}`,
+			want: Cookie{Name: "a", Value: "1", Expiry: time.Unix(1893456000, 0)},
+		},
+		{
+			name: "invalid expiry",
+			data: `{"name":"a","value":"1","expiry":"never"}`,
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Cookie
+
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("got %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCookieHTTPCookie(t *testing.T) {
+	v := Cookie{
+		Name:     "a",
+		Value:    "1",
+		Path:     "/",
+		Domain:   ".example.com",
+		Secure:   true,
+		HTTPOnly: true,
+		Expiry:   time.Unix(1893456000, 0),
+		SameSite: SameSiteNone,
+	}
+
+	want := &http.Cookie{
+		Name:     "a",
+		Value:    "1",
+		Path:     "/",
+		Domain:   ".example.com",
+		Expires:  time.Unix(1893456000, 0),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+	}
+
+	got := v.HTTPCookie()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got.MaxAge != 0 {
+		t.Errorf("got max age %d, want 0", got.MaxAge)
+	}
+
+	if back := CookieFromHTTP(got); !reflect.DeepEqual(back, v) {
+		t.Errorf("got %+v after round trip, want %+v", back, v)
+	}
+}
+
+func TestCookieFromHTTPMaxAge(t *testing.T) {
+	expires := time.Unix(1893456000, 0)
+
+	tests := []struct {
+		name   string
+		hc     *http.Cookie
+		want   time.Time
+		within time.Duration
+	}{
+		{
+			name: "session",
+			hc:   &http.Cookie{Name: "a"},
+		},
+		{
+			name: "expires without max age",
+			hc:   &http.Cookie{Name: "a", Expires: expires},
+			want: expires,
+		},
+		{
+			name:   "max age takes precedence over expires",
+			hc:     &http.Cookie{Name: "a", Expires: expires, MaxAge: 3600},
+			want:   time.Now().Add(time.Hour),
+			within: time.Minute,
+		},
+		{
+			name: "negative max age expires the cookie",
+			hc:   &http.Cookie{Name: "a", Expires: expires, MaxAge: -1},
+			want: time.Unix(1, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CookieFromHTTP(tt.hc).Expiry
+
+			d := got.Sub(tt.want)
+			if d < 0 {
+				d = -d
+			}
+			if got.IsZero() != tt.want.IsZero() || d > tt.within {
+				t.Errorf("got expiry %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
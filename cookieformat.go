@@ -0,0 +1,224 @@
+package wdc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//
+// TYPES
+//
+
+// CookieFormat is a serialization format of a list of cookies.
+type CookieFormat string
+
+const (
+	// CookieFormatNetscape is the Netscape cookies.txt format used by curl, wget and browser extensions.
+	CookieFormatNetscape CookieFormat = "netscape"
+	// CookieFormatJSON is a JSON array of cookies in the WebDriver cookie format.
+	CookieFormatJSON CookieFormat = "json"
+)
+
+const netscapeHeader = "# Netscape HTTP Cookie File"
+
+// netscapeHTTPOnly prefixes the domain of HTTP only cookies in the Netscape format.
+const netscapeHTTPOnly = "#HttpOnly_"
+
+//
+// FUNCTIONS
+//
+
+// EncodeCookies writes cookies cs to w in format f.
+func EncodeCookies(w io.Writer, cs []Cookie, f CookieFormat) error {
+	switch f {
+	case CookieFormatNetscape:
+		return encodeCookiesNetscape(w, cs)
+	case CookieFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if cs == nil {
+			cs = []Cookie{}
+		}
+		return enc.Encode(cs)
+	}
+
+	return fmt.Errorf("unknown cookie format %q", f)
+}
+
+// DecodeCookies reads cookies in format f from r.
+func DecodeCookies(r io.Reader, f CookieFormat) ([]Cookie, error) {
+	switch f {
+	case CookieFormatNetscape:
+		return decodeCookiesNetscape(r)
+	case CookieFormatJSON:
+		var cs []Cookie
+		err := json.NewDecoder(r).Decode(&cs)
+		if err != nil {
+			return nil, err
+		}
+		return cs, nil
+	}
+
+	return nil, fmt.Errorf("unknown cookie format %q", f)
+}
+
+// FilterCookies returns cookies of cs which belong to domain d or its subdomains. If d is empty, all cookies are returned.
+func FilterCookies(cs []Cookie, d string) []Cookie {
+	d = strings.TrimPrefix(d, ".")
+	if d == "" {
+		return cs
+	}
+
+	var res []Cookie
+	for _, v := range cs {
+		cd := strings.TrimPrefix(v.Domain, ".")
+		if cd == d || strings.HasSuffix(cd, "."+d) {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
+
+//
+// METHODS
+//
+
+// CookiesExport writes cookies visible to the current page which belong to domain d to w in format f. If d is empty, all cookies are written.
+func (c *Client) CookiesExport(ctx context.Context, w io.Writer, f CookieFormat, d string) error {
+	if w == nil {
+		return errors.New("writer is empty")
+	}
+
+	cs, err := c.Cookies(ctx)
+	if err != nil {
+		return err
+	}
+
+	return EncodeCookies(w, FilterCookies(cs, d), f)
+}
+
+// CookiesImport reads cookies in format f from r and sets the ones which belong to domain d. If d is empty, all cookies are set.
+//
+// The browser accepts only cookies of the current page domain, so the page should be navigated there first.
+func (c *Client) CookiesImport(ctx context.Context, r io.Reader, f CookieFormat, d string) error {
+	if r == nil {
+		return errors.New("reader is empty")
+	}
+
+	cs, err := DecodeCookies(r, f)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range FilterCookies(cs, d) {
+		err = c.CookieSet(ctx, v)
+		if err != nil {
+			return fmt.Errorf("cookie %s: %w", v.Name, err)
+		}
+	}
+
+	return nil
+}
+
+//
+// UTILS
+//
+
+// encodeCookiesNetscape writes cookies cs to w in the Netscape cookies.txt format.
+func encodeCookiesNetscape(w io.Writer, cs []Cookie) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, netscapeHeader)
+	fmt.Fprintln(bw)
+
+	for _, v := range cs {
+		domain := v.Domain
+		if v.HTTPOnly {
+			domain = netscapeHTTPOnly + domain
+		}
+
+		path := v.Path
+		if path == "" {
+			path = "/"
+		}
+
+		var expiry int64
+		if !v.Expiry.IsZero() {
+			expiry = v.Expiry.Unix()
+		}
+
+		fmt.Fprintf(bw, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			domain, netscapeBool(strings.HasPrefix(v.Domain, ".")), path, netscapeBool(v.Secure), expiry, v.Name, v.Value)
+	}
+
+	return bw.Flush()
+}
+
+// decodeCookiesNetscape reads cookies in the Netscape cookies.txt format from r.
+func decodeCookiesNetscape(r io.Reader) ([]Cookie, error) {
+	var cs []Cookie
+
+	s := bufio.NewScanner(r)
+	n := 0
+
+	for s.Scan() {
+		n++
+		line := strings.TrimRight(s.Text(), "\r")
+
+		httpOnly := strings.HasPrefix(line, netscapeHTTPOnly)
+		if httpOnly {
+			line = strings.TrimPrefix(line, netscapeHTTPOnly)
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		f := strings.Split(line, "\t")
+		if len(f) != 7 {
+			return nil, fmt.Errorf("line %d: expected 7 fields, got %d", n, len(f))
+		}
+
+		expiry, err := strconv.ParseInt(f[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid expiry: %w", n, err)
+		}
+
+		v := Cookie{
+			Domain:   f[0],
+			Path:     f[2],
+			Secure:   strings.EqualFold(f[3], "TRUE"),
+			HTTPOnly: httpOnly,
+			Name:     f[5],
+			Value:    f[6],
+		}
+		if strings.EqualFold(f[1], "TRUE") && !strings.HasPrefix(v.Domain, ".") {
+			v.Domain = "." + v.Domain
+		}
+		if expiry > 0 {
+			v.Expiry = time.Unix(expiry, 0)
+		}
+
+		cs = append(cs, v)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return cs, nil
+}
+
+func netscapeBool(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
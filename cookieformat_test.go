@@ -0,0 +1,107 @@
+package wdc
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCookiesNetscapeRoundTrip(t *testing.T) {
+	cs := []Cookie{
+		{Name: "sid", Value: "abc", Domain: "example.com", Path: "/", Secure: true, HTTPOnly: true, Expiry: time.Unix(1893456000, 0)},
+		{Name: "pref", Value: "dark", Domain: ".example.com", Path: "/app", Expiry: time.Unix(1893456000, 0)},
+		{Name: "session", Value: "1", Domain: "example.com", Path: "/"},
+		{Name: "empty", Value: "", Domain: ".example.com", Path: "/", HTTPOnly: true},
+	}
+
+	b := new(bytes.Buffer)
+	err := EncodeCookies(b, cs, CookieFormatNetscape)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := netscapeHeader + "\n\n" +
+		"#HttpOnly_example.com\tFALSE\t/\tTRUE\t1893456000\tsid\tabc\n" +
+		".example.com\tTRUE\t/app\tFALSE\t1893456000\tpref\tdark\n" +
+		"example.com\tFALSE\t/\tFALSE\t0\tsession\t1\n" +
+		"#HttpOnly_.example.com\tTRUE\t/\tFALSE\t0\tempty\t\n"
+	if b.String() != want {
+		t.Fatalf("got\n%q\nwant\n%q", b.String(), want)
+	}
+
+	got, err := DecodeCookies(b, CookieFormatNetscape)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, cs) {
+		t.Errorf("got %+v, want %+v", got, cs)
+	}
+}
+
+func TestDecodeCookiesNetscape(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []Cookie
+		err   bool
+	}{
+		{
+			name: "CRLF line endings",
+			input: "# Netscape HTTP Cookie File\r\n\r\n" +
+				"example.com\tFALSE\t/\tFALSE\t0\ta\t1\r\n" +
+				"#HttpOnly_example.com\tFALSE\t/\tTRUE\t0\tb\t\r\n",
+			want: []Cookie{
+				{Name: "a", Value: "1", Domain: "example.com", Path: "/"},
+				{Name: "b", Value: "", Domain: "example.com", Path: "/", Secure: true, HTTPOnly: true},
+			},
+		},
+		{
+			name:  "include subdomains adds leading dot",
+			input: "example.com\tTRUE\t/\tFALSE\t1893456000\ta\t1\n",
+			want: []Cookie{
+				{Name: "a", Value: "1", Domain: ".example.com", Path: "/", Expiry: time.Unix(1893456000, 0)},
+			},
+		},
+		{
+			name:  "comments and blank lines",
+			input: "# comment\n\n   \n.example.com\tTRUE\t/\tFALSE\t0\ta\t1\n",
+			want: []Cookie{
+				{Name: "a", Value: "1", Domain: ".example.com", Path: "/"},
+			},
+		},
+		{
+			name:  "empty",
+			input: "",
+		},
+		{
+			name:  "missing field",
+			input: "example.com\tFALSE\t/\tFALSE\t0\ta\n",
+			err:   true,
+		},
+		{
+			name:  "invalid expiry",
+			input: "example.com\tFALSE\t/\tFALSE\tnever\ta\t1\n",
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCookies(strings.NewReader(tt.input), CookieFormatNetscape)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("got %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package wdc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+//
+// TYPES
+//
+
+// CookieJar is a net/http cookie jar backed by the cookies of a browser session.
+//
+// The browser only exposes and accepts cookies of the current page, so the jar works for URLs of the current page domain.
+// Errors can't be returned through the http.CookieJar interface, the last one is available via Err.
+type CookieJar struct {
+	ctx context.Context
+	c   *Client
+
+	mu  sync.Mutex
+	err error
+}
+
+var _ http.CookieJar = (*CookieJar)(nil)
+
+//
+// FUNCTIONS
+//
+
+// NewCookieJar returns a new cookie jar backed by client c. Context ctx is used for all server requests made by the jar.
+func NewCookieJar(ctx context.Context, c *Client) (*CookieJar, error) {
+	if ctx == nil {
+		return nil, errors.New("context is empty")
+	}
+	if c == nil {
+		return nil, errors.New("client is empty")
+	}
+
+	return &CookieJar{ctx: ctx, c: c}, nil
+}
+
+//
+// METHODS
+//
+
+// SetCookies sets cookies cs received in a response from URL u in the browser. Cookies without a domain become host-only cookies of the current page.
+func (j *CookieJar) SetCookies(u *url.URL, cs []*http.Cookie) {
+	for _, hc := range cs {
+		v := CookieFromHTTP(hc)
+		if v.Path == "" {
+			v.Path = defaultCookiePath(u)
+		}
+
+		err := j.c.CookieSet(j.ctx, v)
+		if err != nil {
+			j.setErr(err)
+		}
+	}
+}
+
+// Cookies returns the browser cookies to send in a request to URL u.
+func (j *CookieJar) Cookies(u *url.URL) []*http.Cookie {
+	cs, err := j.c.Cookies(j.ctx)
+	if err != nil {
+		j.setErr(err)
+		return nil
+	}
+
+	var res []*http.Cookie
+	for _, v := range cs {
+		if cookieMatches(v, u, time.Now()) {
+			res = append(res, &http.Cookie{Name: v.Name, Value: v.Value})
+		}
+	}
+
+	return res
+}
+
+// Err returns the last error occurred in SetCookies or Cookies and resets it.
+func (j *CookieJar) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	err := j.err
+	j.err = nil
+
+	return err
+}
+
+func (j *CookieJar) setErr(err error) {
+	j.mu.Lock()
+	j.err = err
+	j.mu.Unlock()
+}
+
+// CookiesToJar copies all cookies visible to the current page into jar, so they are sent by a net/http client.
+//
+// Cookies with a domain without a leading dot are host-only and are sent only to that exact host.
+func (c *Client) CookiesToJar(ctx context.Context, jar http.CookieJar) error {
+	if jar == nil {
+		return errors.New("cookie jar is empty")
+	}
+
+	cs, err := c.Cookies(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range cs {
+		u, hc := jarCookie(v)
+		jar.SetCookies(u, []*http.Cookie{hc})
+	}
+
+	return nil
+}
+
+// CookiesFromJar copies the cookies which jar sends to URL u into the browser.
+//
+// The current page must belong to the domain of u. The jar exposes only names and values, so the cookies are set as host-only cookies with path /.
+func (c *Client) CookiesFromJar(ctx context.Context, jar http.CookieJar, u *url.URL) error {
+	if jar == nil {
+		return errors.New("cookie jar is empty")
+	}
+	if u == nil {
+		return errors.New("URL is empty")
+	}
+
+	for _, hc := range jar.Cookies(u) {
+		err := c.CookieSet(ctx, Cookie{Name: hc.Name, Value: hc.Value, Path: "/", Secure: u.Scheme == "https"})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//
+// UTILS
+//
+
+// jarCookie converts browser cookie v to a net/http cookie and the URL it's set from in a jar.
+//
+// The domain of a host-only cookie is cleared, otherwise the jar would send it to subdomains as well.
+func jarCookie(v Cookie) (*url.URL, *http.Cookie) {
+	u := &url.URL{Scheme: "http", Host: strings.TrimPrefix(v.Domain, "."), Path: v.Path}
+	if v.Secure {
+		u.Scheme = "https"
+	}
+
+	hc := v.HTTPCookie()
+	if !strings.HasPrefix(v.Domain, ".") {
+		hc.Domain = ""
+	}
+
+	return u, hc
+}
+
+// cookieMatches reports whether cookie v should be sent to URL u at time now.
+func cookieMatches(v Cookie, u *url.URL, now time.Time) bool {
+	if !v.Expiry.IsZero() && !v.Expiry.After(now) {
+		return false
+	}
+	if v.Secure && u.Scheme != "https" {
+		return false
+	}
+
+	if v.Domain != "" {
+		host := u.Hostname()
+		d := strings.TrimPrefix(v.Domain, ".")
+		// a domain without a leading dot denotes a host-only cookie
+		if host != d && (d == v.Domain || !strings.HasSuffix(host, "."+d)) {
+			return false
+		}
+	}
+
+	if p := v.Path; p != "" && p != "/" {
+		up := u.EscapedPath()
+		if up == "" {
+			up = "/"
+		}
+		if !strings.HasPrefix(up, p) || (len(up) > len(p) && !strings.HasSuffix(p, "/") && up[len(p)] != '/') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// defaultCookiePath returns the default path of a cookie set by a response from URL u.
+//
+// https://www.rfc-editor.org/rfc/rfc6265#section-5.1.4
+func defaultCookiePath(u *url.URL) string {
+	p := u.EscapedPath()
+	if p == "" || p[0] != '/' {
+		return "/"
+	}
+
+	i := strings.LastIndex(p, "/")
+	if i == 0 {
+		return "/"
+	}
+
+	return p[:i]
+}
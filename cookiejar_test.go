@@ -0,0 +1,207 @@
+package wdc
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCookieMatches(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name string
+		v    Cookie
+		url  string
+		want bool
+	}{
+		{
+			name: "host-only exact host",
+			v:    Cookie{Name: "a", Domain: "example.com"},
+			url:  "http://example.com/",
+			want: true,
+		},
+		{
+			name: "host-only subdomain",
+			v:    Cookie{Name: "a", Domain: "example.com"},
+			url:  "http://api.example.com/",
+		},
+		{
+			name: "domain exact host",
+			v:    Cookie{Name: "a", Domain: ".example.com"},
+			url:  "http://example.com/",
+			want: true,
+		},
+		{
+			name: "domain subdomain",
+			v:    Cookie{Name: "a", Domain: ".example.com"},
+			url:  "http://api.example.com/",
+			want: true,
+		},
+		{
+			name: "domain suffix of another domain",
+			v:    Cookie{Name: "a", Domain: ".example.com"},
+			url:  "http://badexample.com/",
+		},
+		{
+			name: "other domain",
+			v:    Cookie{Name: "a", Domain: "example.com"},
+			url:  "http://example.org/",
+		},
+		{
+			name: "empty domain",
+			v:    Cookie{Name: "a"},
+			url:  "http://example.org/",
+			want: true,
+		},
+		{
+			name: "host with port",
+			v:    Cookie{Name: "a", Domain: "localhost"},
+			url:  "http://localhost:8080/",
+			want: true,
+		},
+		{
+			name: "secure over https",
+			v:    Cookie{Name: "a", Domain: "example.com", Secure: true},
+			url:  "https://example.com/",
+			want: true,
+		},
+		{
+			name: "secure over http",
+			v:    Cookie{Name: "a", Domain: "example.com", Secure: true},
+			url:  "http://example.com/",
+		},
+		{
+			name: "not expired",
+			v:    Cookie{Name: "a", Domain: "example.com", Expiry: now.Add(time.Second)},
+			url:  "http://example.com/",
+			want: true,
+		},
+		{
+			name: "expired",
+			v:    Cookie{Name: "a", Domain: "example.com", Expiry: now},
+			url:  "http://example.com/",
+		},
+		{
+			name: "path exact",
+			v:    Cookie{Name: "a", Domain: "example.com", Path: "/app"},
+			url:  "http://example.com/app",
+			want: true,
+		},
+		{
+			name: "path subdirectory",
+			v:    Cookie{Name: "a", Domain: "example.com", Path: "/app"},
+			url:  "http://example.com/app/page",
+			want: true,
+		},
+		{
+			name: "path with trailing slash",
+			v:    Cookie{Name: "a", Domain: "example.com", Path: "/app/"},
+			url:  "http://example.com/app/page",
+			want: true,
+		},
+		{
+			name: "path prefix of another segment",
+			v:    Cookie{Name: "a", Domain: "example.com", Path: "/app"},
+			url:  "http://example.com/application",
+		},
+		{
+			name: "path of empty URL path",
+			v:    Cookie{Name: "a", Domain: "example.com", Path: "/app"},
+			url:  "http://example.com",
+		},
+		{
+			name: "root path",
+			v:    Cookie{Name: "a", Domain: "example.com", Path: "/"},
+			url:  "http://example.com",
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := cookieMatches(tt.v, u, now)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultCookiePath(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "http://example.com", want: "/"},
+		{url: "http://example.com/", want: "/"},
+		{url: "http://example.com/login", want: "/"},
+		{url: "http://example.com/app/", want: "/app"},
+		{url: "http://example.com/app/login", want: "/app"},
+		{url: "http://example.com/a/b/c?x=/y", want: "/a/b"},
+		{url: "http://example.com/a%2Fb/c", want: "/a%2Fb"},
+		{url: "mailto:user@example.com", want: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := defaultCookiePath(u)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJarCookieHostOnly(t *testing.T) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []Cookie{
+		{Name: "host", Value: "1", Domain: "example.com", Path: "/"},
+		{Name: "domain", Value: "2", Domain: ".example.com", Path: "/"},
+	} {
+		u, hc := jarCookie(v)
+		jar.SetCookies(u, []*http.Cookie{hc})
+	}
+
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{url: "http://example.com/", want: []string{"host", "domain"}},
+		{url: "http://api.example.com/", want: []string{"domain"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			u, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, hc := range jar.Cookies(u) {
+				got = append(got, hc.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
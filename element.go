@@ -32,6 +32,44 @@ type WebElement struct {
 	Reference WebElementReference
 }
 
+// MarshalJSON encodes web element e as a web element reference object, so it can be passed to a script or a command.
+func (e WebElement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[WebElementID]WebElementReference{e.ID: e.Reference})
+}
+
+// isElement reports whether e is a web element reference, not a shadow root, window or frame one. References without an identifier are assumed to be elements.
+func (e WebElement) isElement() bool {
+	return e.ID == "" || e.ID == WebElementIDW3C || e.ID == WebElementIDLegacy
+}
+
+// UnmarshalJSON decodes a web element, shadow root, window or frame reference object returned by the server. W3C identifiers take precedence over the legacy one.
+// JSON null leaves e unchanged.
+func (e *WebElement) UnmarshalJSON(bytes []byte) error {
+	if string(bytes) == "null" {
+		return nil
+	}
+
+	v := map[WebElementID]WebElementReference{}
+
+	err := json.Unmarshal(bytes, &v)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range webElementIDs {
+		if ref, ok := v[id]; ok {
+			*e = WebElement{ID: id, Reference: ref}
+			return nil
+		}
+	}
+	if ref, ok := v[WebElementIDLegacy]; ok {
+		*e = WebElement{ID: WebElementIDLegacy, Reference: ref}
+		return nil
+	}
+
+	return errors.New("value is not a web element reference")
+}
+
 // WebElementID is the string constant defined by the W3C.
 //
 // https://www.w3.org/TR/webdriver/#elements
@@ -42,9 +80,30 @@ const (
 	WebElementIDLegacy WebElementID = "ELEMENT"
 )
 
+// Identifiers of other remote object references which can be returned by scripts. They are represented by WebElement too.
+//
+// https://www.w3.org/TR/webdriver/#shadow-root
+// https://www.w3.org/TR/webdriver/#browsing-context
+const (
+	WebElementIDShadowRoot WebElementID = "shadow-6066-11e4-a52e-4f735466cecf"
+	WebElementIDWindow     WebElementID = "window-fcc6-11e5-b4f8-330a88ab9d7f"
+	WebElementIDFrame      WebElementID = "frame-075b-4da1-b6ba-e579c2d3230a"
+)
+
+// webElementIDs lists identifiers of W3C remote object references in order of precedence.
+var webElementIDs = []WebElementID{WebElementIDW3C, WebElementIDShadowRoot, WebElementIDWindow, WebElementIDFrame}
+
 // WebElementReference represents a reference of a web element.
 type WebElementReference string
 
+// ElementRect represents size and position of an element relative to the document origin in CSS pixels.
+type ElementRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
 //
 // REQUESTS
 //
@@ -70,6 +129,10 @@ type elementResponse struct {
 	Value map[WebElementID]WebElementReference `json:"value"`
 }
 
+type elementRectResponse struct {
+	Value ElementRect `json:"value"`
+}
+
 type elementsResponse struct {
 	Value []map[WebElementID]WebElementReference `json:"value"`
 }
@@ -158,6 +221,8 @@ func (c *Client) ElementWaitForUndefined(ctx context.Context, by LocatorStrategy
 }
 
 // ElementFindShadowDOM command is used to find a shadow root of element e.
+//
+// The legacy endpoint is used if the server speaks the legacy protocol.
 func (c *Client) ElementFindShadowDOM(ctx context.Context, e WebElement) (WebElement, error) {
 	if e.ID == "" {
 		return WebElement{}, errors.New("element web ID is empty")
@@ -165,6 +230,17 @@ func (c *Client) ElementFindShadowDOM(ctx context.Context, e WebElement) (WebEle
 	if e.Reference == "" {
 		return WebElement{}, errors.New("element ID is empty")
 	}
+	if !e.isElement() {
+		return WebElement{}, errors.New("reference is not a web element")
+	}
+
+	legacy, err := c.legacy(ctx)
+	if err != nil {
+		return WebElement{}, err
+	}
+	if legacy {
+		return c.ElementFindShadowDOMLegacy(ctx, e)
+	}
 
 	var args []interface{}
 	args = append(args, map[WebElementID]WebElementReference{
@@ -174,7 +250,7 @@ func (c *Client) ElementFindShadowDOM(ctx context.Context, e WebElement) (WebEle
 	r := &scriptRequest{Script: "return arguments[0].shadowRoot", Args: args}
 
 	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
+	err = json.NewEncoder(b).Encode(r)
 	if err != nil {
 		return WebElement{}, err
 	}
@@ -193,6 +269,9 @@ func (c *Client) ElementFindShadowDOM(ctx context.Context, e WebElement) (WebEle
 		return WebElement{}, err
 	}
 
+	if ref, ok := res.Value[WebElementIDShadowRoot]; ok {
+		return WebElement{ID: WebElementIDShadowRoot, Reference: ref}, nil
+	}
 	if ref, ok := res.Value[WebElementIDW3C]; ok {
 		return WebElement{ID: WebElementIDW3C, Reference: ref}, nil
 	}
@@ -211,6 +290,9 @@ func (c *Client) ElementFindShadowDOMLegacy(ctx context.Context, e WebElement) (
 	if e.Reference == "" {
 		return WebElement{}, errors.New("element ID is empty")
 	}
+	if !e.isElement() {
+		return WebElement{}, errors.New("reference is not a web element")
+	}
 
 	var args []interface{}
 	args = append(args, map[WebElementID]WebElementReference{
@@ -239,6 +321,9 @@ func (c *Client) ElementFindShadowDOMLegacy(ctx context.Context, e WebElement) (
 		return WebElement{}, err
 	}
 
+	if ref, ok := res.Value[WebElementIDShadowRoot]; ok {
+		return WebElement{ID: WebElementIDShadowRoot, Reference: ref}, nil
+	}
 	if ref, ok := res.Value[WebElementIDW3C]; ok {
 		return WebElement{ID: WebElementIDW3C, Reference: ref}, nil
 	}
@@ -301,13 +386,17 @@ func (c *Client) ElementsFind(ctx context.Context, by LocatorStrategy, v string)
 	return elems, nil
 }
 
-// ElementFindFrom command is used to find an element by locator strategy with value v from element e.
+// ElementFindFrom command is used to find an element by locator strategy with value v from element or shadow root e.
 //
 // https://www.w3.org/TR/webdriver/#find-element-from-element
+// https://www.w3.org/TR/webdriver/#find-element-from-shadow-root
 func (c *Client) ElementFindFrom(ctx context.Context, e WebElement, by LocatorStrategy, v string) (WebElement, error) {
 	if e.Reference == "" {
 		return WebElement{}, errors.New("element is empty")
 	}
+	if !e.isElement() && e.ID != WebElementIDShadowRoot {
+		return WebElement{}, errors.New("reference is not a web element or a shadow root")
+	}
 	if by == "" {
 		return WebElement{}, errors.New("locator strategy is empty")
 	}
@@ -324,6 +413,9 @@ func (c *Client) ElementFindFrom(ctx context.Context, e WebElement, by LocatorSt
 	}
 
 	route := fmt.Sprintf("session/%s/element/%s/element", c.session.ID, e.Reference)
+	if e.ID == WebElementIDShadowRoot {
+		route = fmt.Sprintf("session/%s/shadow/%s/element", c.session.ID, e.Reference)
+	}
 
 	req, err := c.prepare(http.MethodPost, route, b)
 	if err != nil {
@@ -347,13 +439,17 @@ func (c *Client) ElementFindFrom(ctx context.Context, e WebElement, by LocatorSt
 	return WebElement{}, ErrorNoSuchElement
 }
 
-// ElementsFindFrom command is used to find elements by locator strategy with value v from an element e.
+// ElementsFindFrom command is used to find elements by locator strategy with value v from an element or shadow root e.
 //
 // https://www.w3.org/TR/webdriver/#find-elements-from-element
+// https://www.w3.org/TR/webdriver/#find-elements-from-shadow-root
 func (c *Client) ElementsFindFrom(ctx context.Context, e WebElement, by LocatorStrategy, v string) ([]WebElement, error) {
 	if e.Reference == "" {
 		return nil, errors.New("element is empty")
 	}
+	if !e.isElement() && e.ID != WebElementIDShadowRoot {
+		return nil, errors.New("reference is not a web element or a shadow root")
+	}
 	if by == "" {
 		return nil, errors.New("locator strategy is empty")
 	}
@@ -370,6 +466,9 @@ func (c *Client) ElementsFindFrom(ctx context.Context, e WebElement, by LocatorS
 	}
 
 	route := fmt.Sprintf("session/%s/element/%s/elements", c.session.ID, e.Reference)
+	if e.ID == WebElementIDShadowRoot {
+		route = fmt.Sprintf("session/%s/shadow/%s/elements", c.session.ID, e.Reference)
+	}
 
 	req, err := c.prepare(http.MethodPost, route, b)
 	if err != nil {
@@ -409,6 +508,9 @@ func (c *Client) ElementClick(ctx context.Context, e WebElement) error {
 	if e.Reference == "" {
 		return errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/click", c.session.ID, e.Reference)
 
@@ -427,6 +529,9 @@ func (c *Client) ElementClear(ctx context.Context, e WebElement) error {
 	if e.Reference == "" {
 		return errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/clear", c.session.ID, e.Reference)
 
@@ -440,19 +545,31 @@ func (c *Client) ElementClear(ctx context.Context, e WebElement) error {
 
 // ElementSendKeys command is used to send provided keys to an element e.
 //
+// The legacy payload is sent if the server speaks the legacy protocol.
 // https://www.w3.org/TR/webdriver/#element-send-keys
 func (c *Client) ElementSendKeys(ctx context.Context, e WebElement, keys string) error {
 	if e.Reference == "" {
 		return errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return errors.New("reference is not a web element")
+	}
 	if keys == "" {
 		return errors.New("keys are empty")
 	}
 
+	legacy, err := c.legacy(ctx)
+	if err != nil {
+		return err
+	}
+	if legacy {
+		return c.ElementSendKeysLegacy(ctx, e, keys)
+	}
+
 	r := &elementSendKeysRequest{Text: keys}
 
 	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
+	err = json.NewEncoder(b).Encode(r)
 	if err != nil {
 		return err
 	}
@@ -481,6 +598,9 @@ func (c *Client) ElementSendKeysLegacy(ctx context.Context, e WebElement, keys s
 	if e.Reference == "" {
 		return errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return errors.New("reference is not a web element")
+	}
 	if len(keys) == 0 {
 		return errors.New("keys are empty")
 	}
@@ -522,6 +642,9 @@ func (c *Client) ElementAttribute(ctx context.Context, e WebElement, attr string
 	if e.Reference == "" {
 		return "", errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return "", errors.New("reference is not a web element")
+	}
 	if attr == "" {
 		return "", errors.New("attribute is empty")
 	}
@@ -550,6 +673,9 @@ func (c *Client) ElementProperty(ctx context.Context, e WebElement, prop string)
 	if e.Reference == "" {
 		return "", errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return "", errors.New("reference is not a web element")
+	}
 	if prop == "" {
 		return "", errors.New("property is empty")
 	}
@@ -578,6 +704,9 @@ func (c *Client) ElementCSSValue(ctx context.Context, e WebElement, prop string)
 	if e.Reference == "" {
 		return "", errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return "", errors.New("reference is not a web element")
+	}
 	if prop == "" {
 		return "", errors.New("CSS property is empty")
 	}
@@ -606,6 +735,9 @@ func (c *Client) ElementText(ctx context.Context, e WebElement) (string, error)
 	if e.Reference == "" {
 		return "", errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return "", errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/text", c.session.ID, e.Reference)
 
@@ -629,6 +761,9 @@ func (c *Client) ElementWaitForText(ctx context.Context, e WebElement, i time.Du
 	if e.Reference == "" {
 		return "", errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return "", errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/text", c.session.ID, e.Reference)
 
@@ -664,6 +799,9 @@ func (c *Client) ElementTagName(ctx context.Context, e WebElement) (string, erro
 	if e.Reference == "" {
 		return "", errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return "", errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/name", c.session.ID, e.Reference)
 
@@ -682,6 +820,34 @@ func (c *Client) ElementTagName(ctx context.Context, e WebElement) (string, erro
 	return res.Value, nil
 }
 
+// ElementRect command is used to get the size and position of an element e.
+//
+// https://www.w3.org/TR/webdriver/#get-element-rect
+func (c *Client) ElementRect(ctx context.Context, e WebElement) (ElementRect, error) {
+	if e.Reference == "" {
+		return ElementRect{}, errors.New("element is empty")
+	}
+	if !e.isElement() {
+		return ElementRect{}, errors.New("reference is not a web element")
+	}
+
+	route := fmt.Sprintf("session/%s/element/%s/rect", c.session.ID, e.Reference)
+
+	req, err := c.prepare(http.MethodGet, route, nil)
+	if err != nil {
+		return ElementRect{}, err
+	}
+
+	res := new(elementRectResponse)
+
+	err = c.do(ctx, req, res)
+	if err != nil {
+		return ElementRect{}, err
+	}
+
+	return res.Value, nil
+}
+
 // ElementScreenshot command is used to take a screenshot of an element e.
 //
 // https://www.w3.org/TR/webdriver/#take-element-screenshot
@@ -689,6 +855,9 @@ func (c *Client) ElementScreenshot(ctx context.Context, e WebElement) (string, e
 	if e.Reference == "" {
 		return "", errors.New("element ID is empty")
 	}
+	if !e.isElement() {
+		return "", errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/screenshot", c.session.ID, e.Reference)
 
@@ -714,6 +883,9 @@ func (c *Client) ElementIsSelected(ctx context.Context, e WebElement) (bool, err
 	if e.Reference == "" {
 		return false, errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return false, errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/selected", c.session.ID, e.Reference)
 
@@ -739,6 +911,9 @@ func (c *Client) ElementIsEnabled(ctx context.Context, e WebElement) (bool, erro
 	if e.Reference == "" {
 		return false, errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return false, errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/enabled", c.session.ID, e.Reference)
 
@@ -762,6 +937,9 @@ func (c *Client) ElementWaitForEnabled(ctx context.Context, e WebElement, i time
 	if e.Reference == "" {
 		return errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/enabled", c.session.ID, e.Reference)
 
@@ -797,6 +975,9 @@ func (c *Client) ElementIsDisplayed(ctx context.Context, e WebElement) (bool, er
 	if e.Reference == "" {
 		return false, errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return false, errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/displayed", c.session.ID, e.Reference)
 
@@ -820,6 +1001,9 @@ func (c *Client) ElementWaitForDisplayed(ctx context.Context, e WebElement, i ti
 	if e.Reference == "" {
 		return errors.New("element is empty")
 	}
+	if !e.isElement() {
+		return errors.New("reference is not a web element")
+	}
 
 	route := fmt.Sprintf("session/%s/element/%s/displayed", c.session.ID, e.Reference)
 
@@ -4,50 +4,337 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 )
 
+//
+// TYPES
+//
+
+// FrameLocator describes how to locate a frame element (iframe or frame) within its parent browsing context.
+type FrameLocator struct {
+	By    LocatorStrategy
+	Value string
+}
+
+// framesXPath matches all frame elements of a document in the same order as they are indexed by the driver.
+const framesXPath = "//iframe|//frame"
+
 //
 // REQUESTS
 //
 
+// frameChangeRequest holds a frame id which can be null, a number or a web element reference.
 type frameChangeRequest struct {
-	ID int `json:"id"`
+	ID interface{} `json:"id"`
 }
 
+//
+// METHODS
+//
+
 // SwitchToFrame command switches the active frame to a nested frame by index fi. The active frame receives commands.
 //
 // https://www.w3.org/TR/webdriver/#switch-to-frame
 func (c *Client) SwitchToFrame(ctx context.Context, fi int) error {
-	r := &frameChangeRequest{ID: fi}
+	if fi < 0 {
+		return errors.New("frame index is negative")
+	}
 
-	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
+	return c.enterFrame(ctx, fi)
+}
+
+// SwitchToFrameElement command switches the active frame to a nested frame represented by frame or iframe element e. The active frame receives commands.
+//
+// https://www.w3.org/TR/webdriver/#switch-to-frame
+func (c *Client) SwitchToFrameElement(ctx context.Context, e WebElement) error {
+	if e.ID == "" {
+		return errors.New("element web ID is empty")
+	}
+	if e.Reference == "" {
+		return errors.New("element is empty")
+	}
+	if !e.isElement() {
+		return errors.New("reference is not a web element")
+	}
+
+	return c.enterFrame(ctx, e)
+}
+
+// SwitchToFrameTop command switches the active frame to the top-level browsing context. The active frame receives commands.
+//
+// https://www.w3.org/TR/webdriver/#switch-to-frame
+func (c *Client) SwitchToFrameTop(ctx context.Context) error {
+	err := c.switchToFrame(ctx, nil)
 	if err != nil {
 		return err
 	}
 
-	route := fmt.Sprintf("session/%s/frame", c.session.ID)
+	c.setFrames(nil)
 
-	req, err := c.prepare(http.MethodPost, route, b)
+	return nil
+}
+
+// SwitchToFramePath command switches the active frame to the top-level browsing context and then descends through the nested frames located by locators ls.
+//
+// Every locator is resolved within the frame selected by the previous one. If ls is empty, the top-level browsing context becomes active.
+func (c *Client) SwitchToFramePath(ctx context.Context, ls ...FrameLocator) error {
+	err := c.SwitchToFrameTop(ctx)
 	if err != nil {
 		return err
 	}
 
-	return c.do(ctx, req, nil)
+	for i, l := range ls {
+		err = c.enterFrame(ctx, l)
+		if err != nil {
+			return fmt.Errorf("frame path step %d (%s %q): %w", i, l.By, l.Value, err)
+		}
+	}
+
+	return nil
 }
 
 // SwitchToParentFrame command switches the active frame to the parent frame. The active frame receives commands.
 //
 // https://www.w3.org/TR/webdriver/#switch-to-parent-frame
 func (c *Client) SwitchToParentFrame(ctx context.Context) error {
-	route := fmt.Sprintf("/session/%s/frame/parent", c.session.ID)
+	route := fmt.Sprintf("session/%s/frame/parent", c.session.ID)
 
 	req, err := c.prepare(http.MethodPost, route, nil)
 	if err != nil {
 		return err
 	}
 
+	err = c.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if n := len(c.frames); n > 0 {
+		c.frames = c.frames[:n-1]
+	}
+	c.mu.Unlock()
+
+	return nil
+}
+
+// WithinFrame switches the active frame to a nested frame located by l, runs fn and then restores the previously active frame.
+//
+// The previous frame is restored even if fn returns an error or switches to another frame itself.
+func (c *Client) WithinFrame(ctx context.Context, l FrameLocator, fn func() error) (err error) {
+	prev := c.framePath()
+
+	err = c.enterFrame(ctx, l)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = withRestore(err, c.restoreFrames(ctx, prev))
+	}()
+
+	return fn()
+}
+
+// ElementFindAnyFrame command is used to find an element by locator strategy with value v in the top-level browsing context or any of its nested frames.
+//
+// Frames are walked depth-first starting from the top-level browsing context. The element is returned together with the frame path where it was found,
+// which can be passed to SwitchToFramePath later. The session stays switched into that frame unless restore is true,
+// in which case the previously active frame is selected again. If the element is not found, the previously active frame is always restored.
+// Note that the implicit wait timeout applies to every visited frame.
+func (c *Client) ElementFindAnyFrame(ctx context.Context, by LocatorStrategy, v string, restore bool) (WebElement, []FrameLocator, error) {
+	if by == "" {
+		return WebElement{}, nil, errors.New("locator strategy is empty")
+	}
+	if v == "" {
+		return WebElement{}, nil, errors.New("value is empty")
+	}
+
+	prev := c.framePath()
+
+	err := c.SwitchToFrameTop(ctx)
+	if err != nil {
+		return WebElement{}, nil, err
+	}
+
+	e, p, err := c.elementFindFrames(ctx, by, v, nil)
+	if err == nil && !restore {
+		return e, p, nil
+	}
+
+	err = withRestore(err, c.restoreFrames(ctx, prev))
+	if err != nil {
+		return WebElement{}, nil, err
+	}
+
+	return e, p, nil
+}
+
+// elementFindFrames searches an element in the active frame with path p and then in its nested frames recursively.
+//
+// When the element is found, the frame it belongs to stays active.
+func (c *Client) elementFindFrames(ctx context.Context, by LocatorStrategy, v string, p []FrameLocator) (WebElement, []FrameLocator, error) {
+	e, err := c.ElementFind(ctx, by, v)
+	if err == nil {
+		return e, p, nil
+	}
+	if !errors.Is(err, ErrorNoSuchElement) {
+		return WebElement{}, nil, err
+	}
+
+	frames, err := c.ElementsFind(ctx, ByXPath, framesXPath)
+	if errors.Is(err, ErrorNoSuchElement) {
+		return WebElement{}, nil, ErrorNoSuchElement
+	}
+	if err != nil {
+		return WebElement{}, nil, err
+	}
+
+	for i, f := range frames {
+		l := FrameLocator{By: ByXPath, Value: fmt.Sprintf("(%s)[%d]", framesXPath, i+1)}
+
+		err = c.switchToFrame(ctx, map[WebElementID]WebElementReference{f.ID: f.Reference})
+		if errors.Is(err, ErrorNoSuchFrame) || errors.Is(err, ErrorStaleElementReference) {
+			continue // frame has gone away in the meantime
+		}
+		if err != nil {
+			return WebElement{}, nil, err
+		}
+
+		c.pushFrame(l)
+
+		fp := append(append([]FrameLocator(nil), p...), l)
+
+		e, fp, err = c.elementFindFrames(ctx, by, v, fp)
+		if err == nil {
+			return e, fp, nil
+		}
+		if !errors.Is(err, ErrorNoSuchElement) {
+			return WebElement{}, nil, err
+		}
+
+		err = c.SwitchToParentFrame(ctx)
+		if err != nil {
+			return WebElement{}, nil, err
+		}
+	}
+
+	return WebElement{}, nil, ErrorNoSuchElement
+}
+
+// enterFrame switches the active frame to a nested frame identified by step and records it in the frame path.
+//
+// The step is either a frame index, a frame WebElement or a FrameLocator.
+func (c *Client) enterFrame(ctx context.Context, step interface{}) error {
+	var id interface{}
+
+	switch v := step.(type) {
+	case int:
+		id = v
+	case WebElement:
+		id = map[WebElementID]WebElementReference{v.ID: v.Reference}
+	case FrameLocator:
+		e, err := c.ElementFind(ctx, v.By, v.Value)
+		if err != nil {
+			return err
+		}
+		id = map[WebElementID]WebElementReference{e.ID: e.Reference}
+	default:
+		return fmt.Errorf("unsupported frame step %T", step)
+	}
+
+	err := c.switchToFrame(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	c.pushFrame(step)
+
+	return nil
+}
+
+// restoreFrames makes the frame path p active again.
+//
+// When the active frame is a direct child of p, the parent frame is selected, otherwise p is replayed from the top-level browsing context.
+func (c *Client) restoreFrames(ctx context.Context, p []interface{}) error {
+	cur := c.framePath()
+
+	if framesEqual(cur, p) {
+		return nil
+	}
+	if len(cur) == len(p)+1 && framesEqual(cur[:len(p)], p) {
+		return c.SwitchToParentFrame(ctx)
+	}
+
+	err := c.SwitchToFrameTop(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range p {
+		err = c.enterFrame(ctx, step)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// switchToFrame sends the switch to frame command with frame id.
+func (c *Client) switchToFrame(ctx context.Context, id interface{}) error {
+	r := &frameChangeRequest{ID: id}
+
+	b := new(bytes.Buffer)
+	err := json.NewEncoder(b).Encode(r)
+	if err != nil {
+		return err
+	}
+
+	route := fmt.Sprintf("session/%s/frame", c.session.ID)
+
+	req, err := c.prepare(http.MethodPost, route, b)
+	if err != nil {
+		return err
+	}
+
 	return c.do(ctx, req, nil)
 }
+
+// framePath returns a copy of the current frame path.
+func (c *Client) framePath() []interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]interface{}(nil), c.frames...)
+}
+
+// pushFrame appends step to the current frame path.
+func (c *Client) pushFrame(step interface{}) {
+	c.mu.Lock()
+	c.frames = append(c.frames, step)
+	c.mu.Unlock()
+}
+
+// setFrames replaces the current frame path with p.
+func (c *Client) setFrames(p []interface{}) {
+	c.mu.Lock()
+	c.frames = p
+	c.mu.Unlock()
+}
+
+// framesEqual reports whether frame paths a and b are the same.
+func framesEqual(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -1 +1,3 @@
 module github.com/codedius/wdc
+
+go 1.18
@@ -0,0 +1,166 @@
+package wdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+//
+// TYPES
+//
+
+// LogType is a type of a log provided by the server.
+type LogType string
+
+const (
+	LogBrowser     LogType = "browser"
+	LogDriver      LogType = "driver"
+	LogClient      LogType = "client"
+	LogServer      LogType = "server"
+	LogPerformance LogType = "performance"
+)
+
+// LogEntry is an entry of a log provided by the server.
+type LogEntry struct {
+	// Level is a severity of the entry, e.g. SEVERE, WARNING, INFO.
+	Level string
+	// Message of the entry. Performance log messages are JSON encoded events.
+	Message   string
+	Source    string
+	Timestamp time.Time
+}
+
+//
+// REQUESTS
+//
+
+type logRequest struct {
+	Type LogType `json:"type"`
+}
+
+//
+// RESPONSES
+//
+
+type logTypesResponse struct {
+	Value []LogType `json:"value"`
+}
+
+type logResponse struct {
+	Value []struct {
+		Level     string  `json:"level"`
+		Message   string  `json:"message"`
+		Source    string  `json:"source"`
+		Timestamp float64 `json:"timestamp"`
+	} `json:"value"`
+}
+
+//
+// METHODS
+//
+
+// LogTypes command is used to get the types of logs available for the session.
+//
+// It's a legacy command which is not a part of the W3C specification. If the server doesn't support it, an error wrapping ErrorUnknownCommand is returned.
+// https://github.com/SeleniumHQ/selenium/wiki/JsonWireProtocol#sessionsessionidlogtypes
+func (c *Client) LogTypes(ctx context.Context) ([]LogType, error) {
+	route := fmt.Sprintf("session/%s/log/types", c.session.ID)
+
+	req, err := c.prepare(http.MethodGet, route, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(logTypesResponse)
+
+	err = c.do(ctx, req, res)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	return res.Value, nil
+}
+
+// Logs command is used to get the log of type t. The server clears the returned entries, so every call returns only new ones.
+//
+// It's a legacy command which is not a part of the W3C specification. If the server doesn't support it, an error wrapping ErrorUnknownCommand is returned.
+// https://github.com/SeleniumHQ/selenium/wiki/JsonWireProtocol#sessionsessionidlog
+func (c *Client) Logs(ctx context.Context, t LogType) ([]LogEntry, error) {
+	if t == "" {
+		return nil, errors.New("log type is empty")
+	}
+
+	r := &logRequest{Type: t}
+
+	b := new(bytes.Buffer)
+	err := json.NewEncoder(b).Encode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	route := fmt.Sprintf("session/%s/log", c.session.ID)
+
+	req, err := c.prepare(http.MethodPost, route, b)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(logResponse)
+
+	err = c.do(ctx, req, res)
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	entries := make([]LogEntry, len(res.Value))
+	for i, e := range res.Value {
+		entries[i] = LogEntry{
+			Level:     e.Level,
+			Message:   e.Message,
+			Source:    e.Source,
+			Timestamp: time.UnixMilli(int64(e.Timestamp)),
+		}
+	}
+
+	return entries, nil
+}
+
+// LogsAll command is used to get all logs available for the session by their types.
+//
+// If the server doesn't support logs, an empty map is returned without an error.
+func (c *Client) LogsAll(ctx context.Context) (map[LogType][]LogEntry, error) {
+	types, err := c.LogTypes(ctx)
+	if errors.Is(err, ErrorUnknownCommand) {
+		return map[LogType][]LogEntry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(map[LogType][]LogEntry, len(types))
+	for _, t := range types {
+		entries, err := c.Logs(ctx, t)
+		if errors.Is(err, ErrorUnknownCommand) || errors.Is(err, ErrorInvalidArgument) {
+			continue // type is advertised but can't be read
+		}
+		if err != nil {
+			return nil, err
+		}
+		logs[t] = entries
+	}
+
+	return logs, nil
+}
+
+// logError makes the server errors caused by the unsupported log commands match ErrorUnknownCommand.
+func logError(err error) error {
+	if errors.Is(err, ErrorUnknownMethod) || errors.Is(err, ErrorUnsupportedOperation) {
+		return fmt.Errorf("%w: %v", ErrorUnknownCommand, err)
+	}
+	return err
+}
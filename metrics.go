@@ -0,0 +1,136 @@
+package wdc
+
+import (
+	"context"
+	"encoding/json"
+)
+
+//
+// TYPES
+//
+
+// PageMetrics contains performance metrics of the current page. All times are in milliseconds relative to the navigation start.
+type PageMetrics struct {
+	Navigation *NavigationTiming `json:"navigation,omitempty"`
+	Resources  []ResourceTiming  `json:"resources"`
+	Paint      []PaintTiming     `json:"paint"`
+	// FCP is the First Contentful Paint time, zero if it's not available.
+	FCP float64 `json:"fcp"`
+	// LCP is the Largest Contentful Paint time, zero if it's not available.
+	LCP float64 `json:"lcp"`
+	// CLS is the Cumulative Layout Shift score, i.e. the largest session window of layout shifts.
+	CLS float64 `json:"cls"`
+}
+
+// NavigationTiming is a navigation timing entry of the document.
+//
+// https://www.w3.org/TR/navigation-timing-2/
+type NavigationTiming struct {
+	Name                       string  `json:"name"`
+	Type                       string  `json:"type"`
+	StartTime                  float64 `json:"startTime"`
+	Duration                   float64 `json:"duration"`
+	RedirectCount              int     `json:"redirectCount"`
+	DomainLookupStart          float64 `json:"domainLookupStart"`
+	DomainLookupEnd            float64 `json:"domainLookupEnd"`
+	ConnectStart               float64 `json:"connectStart"`
+	ConnectEnd                 float64 `json:"connectEnd"`
+	SecureConnectionStart      float64 `json:"secureConnectionStart"`
+	RequestStart               float64 `json:"requestStart"`
+	ResponseStart              float64 `json:"responseStart"`
+	ResponseEnd                float64 `json:"responseEnd"`
+	DOMInteractive             float64 `json:"domInteractive"`
+	DOMContentLoadedEventStart float64 `json:"domContentLoadedEventStart"`
+	DOMContentLoadedEventEnd   float64 `json:"domContentLoadedEventEnd"`
+	DOMComplete                float64 `json:"domComplete"`
+	LoadEventStart             float64 `json:"loadEventStart"`
+	LoadEventEnd               float64 `json:"loadEventEnd"`
+	TransferSize               int64   `json:"transferSize"`
+	EncodedBodySize            int64   `json:"encodedBodySize"`
+	DecodedBodySize            int64   `json:"decodedBodySize"`
+}
+
+// ResourceTiming is a timing entry of a resource loaded by the document.
+//
+// https://www.w3.org/TR/resource-timing-2/
+type ResourceTiming struct {
+	Name            string  `json:"name"`
+	InitiatorType   string  `json:"initiatorType"`
+	StartTime       float64 `json:"startTime"`
+	Duration        float64 `json:"duration"`
+	RequestStart    float64 `json:"requestStart"`
+	ResponseStart   float64 `json:"responseStart"`
+	ResponseEnd     float64 `json:"responseEnd"`
+	TransferSize    int64   `json:"transferSize"`
+	EncodedBodySize int64   `json:"encodedBodySize"`
+	DecodedBodySize int64   `json:"decodedBodySize"`
+}
+
+// PaintTiming is a paint timing entry, e.g. first-paint or first-contentful-paint.
+//
+// https://www.w3.org/TR/paint-timing/
+type PaintTiming struct {
+	Name      string  `json:"name"`
+	StartTime float64 `json:"startTime"`
+}
+
+// metricsScript collects timing entries and observes buffered LCP and layout shift entries which are delivered asynchronously.
+const metricsScript = `var done = arguments[arguments.length - 1];
+var json = function(e) { return e.toJSON ? e.toJSON() : e; };
+var res = {
+	navigation: (performance.getEntriesByType('navigation') || []).map(json)[0] || null,
+	resources: performance.getEntriesByType('resource').map(json),
+	paint: performance.getEntriesByType('paint').map(json),
+	lcp: 0,
+	cls: 0
+};
+var session = 0, first = 0, last = 0;
+var observe = function(type, fn) {
+	try {
+		new PerformanceObserver(function(l) { l.getEntries().forEach(fn); }).observe({type: type, buffered: true});
+	} catch (e) {}
+};
+observe('largest-contentful-paint', function(e) {
+	res.lcp = Math.max(res.lcp, e.renderTime || e.loadTime || e.startTime);
+});
+observe('layout-shift', function(e) {
+	if (e.hadRecentInput) return;
+	if (session && e.startTime - last < 1000 && e.startTime - first < 5000) {
+		session += e.value;
+	} else {
+		session = e.value;
+		first = e.startTime;
+	}
+	last = e.startTime;
+	res.cls = Math.max(res.cls, session);
+});
+setTimeout(function() { done(JSON.stringify(res)); }, 100);`
+
+//
+// METHODS
+//
+
+// PageMetrics collects Navigation Timing, Resource Timing, Paint Timing and Web Vitals (LCP and CLS) of the current page.
+//
+// LCP and CLS are reported by the browser only if it supports the corresponding PerformanceObserver entry types.
+func (c *Client) PageMetrics(ctx context.Context) (PageMetrics, error) {
+	s, err := c.PageScriptAsync(ctx, metricsScript, nil)
+	if err != nil {
+		return PageMetrics{}, err
+	}
+
+	m := PageMetrics{}
+
+	err = json.Unmarshal([]byte(s), &m)
+	if err != nil {
+		return PageMetrics{}, err
+	}
+
+	for _, p := range m.Paint {
+		if p.Name == "first-contentful-paint" {
+			m.FCP = p.StartTime
+		}
+	}
+
+	return m, nil
+}
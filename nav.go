@@ -44,7 +44,9 @@ func (c *Client) NavigateTo(ctx context.Context, url string) error {
 		return err
 	}
 
-	return c.do(ctx, req, nil)
+	err = c.do(ctx, req, nil)
+
+	return c.navigated(ctx, err)
 }
 
 // NavigateBack command is used to navigate backwards in the browser history, if possible.
@@ -59,7 +61,9 @@ func (c *Client) NavigateBack(ctx context.Context) error {
 		return err
 	}
 
-	return c.do(ctx, req, nil)
+	err = c.do(ctx, req, nil)
+
+	return c.navigated(ctx, err)
 }
 
 // NavigateForward command is used to navigate forwards in the browser history, if possible.
@@ -74,5 +78,7 @@ func (c *Client) NavigateForward(ctx context.Context) error {
 		return err
 	}
 
-	return c.do(ctx, req, nil)
+	err = c.do(ctx, req, nil)
+
+	return c.navigated(ctx, err)
 }
@@ -33,7 +33,9 @@ func (c *Client) PageRefresh(ctx context.Context) error {
 		return err
 	}
 
-	return c.do(ctx, req, nil)
+	err = c.do(ctx, req, nil)
+
+	return c.navigated(ctx, err)
 }
 
 // PageURL command is used to retrieve the URL of the current page.
@@ -124,16 +126,25 @@ func (c *Client) PageScreenshot(ctx context.Context) (string, error) {
 // PageScript command is used to inject a snippet of JavaScript s with arguments args into the page for execution in the context of the currently selected frame.
 //
 // The executed script is assumed to be synchronous and the result of evaluating the script is returned to the client.
+// The legacy endpoint is used if the server speaks the legacy protocol.
 // https://www.w3.org/TR/webdriver/#execute-script
 func (c *Client) PageScript(ctx context.Context, s string, args []interface{}) (string, error) {
 	if s == "" {
 		return "", errors.New("script is empty")
 	}
 
+	legacy, err := c.legacy(ctx)
+	if err != nil {
+		return "", err
+	}
+	if legacy {
+		return c.PageScriptLegacy(ctx, s, args)
+	}
+
 	r := &scriptRequest{Script: s, Args: prepareScriptArguments(args)}
 
 	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
+	err = json.NewEncoder(b).Encode(r)
 	if err != nil {
 		return "", err
 	}
@@ -192,16 +203,25 @@ func (c *Client) PageScriptLegacy(ctx context.Context, s string, args []interfac
 // PageScriptAsync command is used to inject a snippet of JavaScript s with arguments args into the page for execution in the context of the currently selected frame.
 //
 // The executed script is assumed to be asynchronous and must signal that is done by invoking the provided callback, which is always provided as the final argument to the function. The value to this callback will be returned to the client.
+// The legacy endpoint is used if the server speaks the legacy protocol.
 // https://www.w3.org/TR/webdriver/#execute-script
 func (c *Client) PageScriptAsync(ctx context.Context, s string, args []interface{}) (string, error) {
 	if s == "" {
 		return "", errors.New("script is empty")
 	}
 
+	legacy, err := c.legacy(ctx)
+	if err != nil {
+		return "", err
+	}
+	if legacy {
+		return c.PageScriptAsyncLegacy(ctx, s, args)
+	}
+
 	r := &scriptRequest{Script: s, Args: prepareScriptArguments(args)}
 
 	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
+	err = json.NewEncoder(b).Encode(r)
 	if err != nil {
 		return "", err
 	}
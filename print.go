@@ -0,0 +1,113 @@
+package wdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+//
+// TYPES
+//
+
+// PrintOrientation is an orientation of printed pages.
+type PrintOrientation string
+
+const (
+	PrintPortrait  PrintOrientation = "portrait"
+	PrintLandscape PrintOrientation = "landscape"
+)
+
+// PrintOptions describes how the page is printed. Zero fields fall back to the server defaults.
+//
+// https://www.w3.org/TR/webdriver/#print-page
+type PrintOptions struct {
+	// Orientation of pages, portrait by default.
+	Orientation PrintOrientation `json:"orientation,omitempty"`
+	// Scale of the page content in range 0.1 - 2, 1 by default.
+	Scale float64 `json:"scale,omitempty"`
+	// Background enables printing of background colors and images.
+	Background bool `json:"background,omitempty"`
+	// Page is a size of a printed page, US letter by default.
+	Page *PrintPage `json:"page,omitempty"`
+	// Margin is a size of page margins, 1 cm on each side by default.
+	Margin *PrintMargin `json:"margin,omitempty"`
+	// PageRanges lists pages to print, e.g. "1", "3-5". All pages are printed by default.
+	PageRanges []string `json:"pageRanges,omitempty"`
+	// ShrinkToFit resizes the content to match the page width, enabled by default.
+	ShrinkToFit *bool `json:"shrinkToFit,omitempty"`
+}
+
+// PrintPage is a size of a printed page in centimeters.
+type PrintPage struct {
+	Width  float64 `json:"width,omitempty"`
+	Height float64 `json:"height,omitempty"`
+}
+
+// PrintMargin is a size of printed page margins in centimeters.
+type PrintMargin struct {
+	Top    float64 `json:"top"`
+	Bottom float64 `json:"bottom"`
+	Left   float64 `json:"left"`
+	Right  float64 `json:"right"`
+}
+
+// Common page sizes in centimeters.
+var (
+	PrintPageA4     = PrintPage{Width: 21, Height: 29.7}
+	PrintPageLetter = PrintPage{Width: 21.59, Height: 27.94}
+	PrintPageLegal  = PrintPage{Width: 21.59, Height: 35.56}
+)
+
+//
+// METHODS
+//
+
+// PagePrint command is used to render the current page as a paginated PDF document with options o and return it.
+//
+// https://www.w3.org/TR/webdriver/#print-page
+func (c *Client) PagePrint(ctx context.Context, o PrintOptions) ([]byte, error) {
+	b := new(bytes.Buffer)
+
+	err := c.PagePrintTo(ctx, b, o)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// PagePrintTo command is used to render the current page as a paginated PDF document with options o and write it to w.
+//
+// https://www.w3.org/TR/webdriver/#print-page
+func (c *Client) PagePrintTo(ctx context.Context, w io.Writer, o PrintOptions) error {
+	if w == nil {
+		return errors.New("writer is empty")
+	}
+	if o.Scale != 0 && (o.Scale < 0.1 || o.Scale > 2) {
+		return errors.New("print scale is out of range")
+	}
+	if o.Page != nil && (o.Page.Width < 0 || o.Page.Height < 0) {
+		return errors.New("print page size is negative")
+	}
+	if m := o.Margin; m != nil && (m.Top < 0 || m.Bottom < 0 || m.Left < 0 || m.Right < 0) {
+		return errors.New("print margin is negative")
+	}
+
+	b := new(bytes.Buffer)
+	err := json.NewEncoder(b).Encode(o)
+	if err != nil {
+		return err
+	}
+
+	route := fmt.Sprintf("session/%s/print", c.session.ID)
+
+	return c.base64Value(ctx, http.MethodPost, route, b, func(r io.Reader) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+}
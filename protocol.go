@@ -0,0 +1,140 @@
+package wdc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+//
+// TYPES
+//
+
+// Protocol is a dialect spoken by a remote web driver server.
+type Protocol string
+
+const (
+	// ProtocolUnknown means the protocol is detected on the first command which depends on it.
+	ProtocolUnknown Protocol = ""
+	// ProtocolW3C is the W3C WebDriver protocol.
+	//
+	// https://www.w3.org/TR/webdriver/
+	ProtocolW3C Protocol = "w3c"
+	// ProtocolLegacy is the Selenium JSON Wire protocol.
+	//
+	// https://github.com/SeleniumHQ/selenium/wiki/JsonWireProtocol
+	ProtocolLegacy Protocol = "legacy"
+)
+
+//
+// FUNCTIONS
+//
+
+// ProtocolOf detects the protocol from the raw body b of a new session response.
+//
+// W3C servers wrap the session ID and capabilities into the value field, while legacy servers return the session ID at the top level.
+func ProtocolOf(b []byte) (Protocol, error) {
+	res := struct {
+		SessionID string `json:"sessionId"`
+		Value     struct {
+			SessionID string `json:"sessionId"`
+		} `json:"value"`
+	}{}
+
+	err := json.Unmarshal(b, &res)
+	if err != nil {
+		return ProtocolUnknown, err
+	}
+
+	switch {
+	case res.Value.SessionID != "":
+		return ProtocolW3C, nil
+	case res.SessionID != "":
+		return ProtocolLegacy, nil
+	}
+
+	return ProtocolUnknown, fmt.Errorf("no session ID in new session response")
+}
+
+//
+// METHODS
+//
+
+// Protocol returns the protocol used to talk to the server, detecting it if needed.
+func (c *Client) Protocol(ctx context.Context) (Protocol, error) {
+	c.mu.Lock()
+	p := c.protocol
+	c.mu.Unlock()
+
+	if p != ProtocolUnknown {
+		return p, nil
+	}
+
+	p, err := c.DetectProtocol(ctx)
+	if err != nil {
+		return ProtocolUnknown, err
+	}
+
+	c.SetProtocol(p)
+
+	return p, nil
+}
+
+// SetProtocol overrides the protocol p used to talk to the server. ProtocolUnknown resets it to be detected again.
+func (c *Client) SetProtocol(p Protocol) {
+	c.mu.Lock()
+	c.protocol = p
+	c.mu.Unlock()
+}
+
+// DetectProtocol probes the server to find out the protocol it speaks for the current session.
+//
+// Pure W3C servers are recognized by the status response. Servers which speak both dialects are probed with a session command,
+// since only legacy responses contain the status field.
+func (c *Client) DetectProtocol(ctx context.Context) (Protocol, error) {
+	res, err := c.probe(ctx, "status")
+	if err == nil {
+		if _, ok := res["status"]; !ok {
+			return ProtocolW3C, nil
+		}
+	}
+
+	res, err = c.probe(ctx, fmt.Sprintf("session/%s/url", c.session.ID))
+	if err != nil {
+		return ProtocolUnknown, err
+	}
+
+	if _, ok := res["status"]; ok {
+		return ProtocolLegacy, nil
+	}
+
+	return ProtocolW3C, nil
+}
+
+// probe sends a GET request to route and returns top-level fields of the response.
+func (c *Client) probe(ctx context.Context, route string) (map[string]json.RawMessage, error) {
+	req, err := c.prepare(http.MethodGet, route, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]json.RawMessage{}
+
+	err = c.do(ctx, req, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// legacy reports whether the server speaks the legacy protocol.
+func (c *Client) legacy(ctx context.Context) (bool, error) {
+	p, err := c.Protocol(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	return p == ProtocolLegacy, nil
+}
@@ -0,0 +1,311 @@
+package wdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"time"
+)
+
+//
+// TYPES
+//
+
+// FullPageOptions describes how a full page screenshot is captured.
+type FullPageOptions struct {
+	// HideFixed hides fixed and sticky elements (e.g. headers) on all tiles but the first one, so they appear only once.
+	HideFixed bool
+	// Delay is an amount of time to wait after every scroll, e.g. for lazy loaded content.
+	Delay time.Duration
+	// MaxHeight limits the captured page height in CSS pixels. Zero means no limit.
+	MaxHeight int
+}
+
+type fullPageMetrics struct {
+	ScrollX    float64 `json:"scrollX"`
+	ScrollY    float64 `json:"scrollY"`
+	Height     float64 `json:"height"`
+	ViewWidth  float64 `json:"viewWidth"`
+	ViewHeight float64 `json:"viewHeight"`
+}
+
+const fullPageMetricsScript = `var d = document.documentElement, b = document.body || d;
+return {
+	scrollX: window.pageXOffset,
+	scrollY: window.pageYOffset,
+	height: Math.max(d.scrollHeight, b.scrollHeight, d.offsetHeight, b.offsetHeight),
+	viewWidth: window.innerWidth,
+	viewHeight: d.clientHeight || window.innerHeight
+};`
+
+const fullPageScrollScript = `window.scrollTo(arguments[0], arguments[1]);
+return window.pageYOffset;`
+
+const fullPageHideFixedScript = `var hide = arguments[0], attr = 'data-wdc-visibility';
+if (hide) {
+	document.querySelectorAll('body *').forEach(function(e) {
+		var p = window.getComputedStyle(e).position;
+		if (p === 'fixed' || p === 'sticky') {
+			e.setAttribute(attr, e.style.visibility);
+			e.style.visibility = 'hidden';
+		}
+	});
+} else {
+	document.querySelectorAll('[' + attr + ']').forEach(function(e) {
+		e.style.visibility = e.getAttribute(attr);
+		e.removeAttribute(attr);
+	});
+}`
+
+//
+// METHODS
+//
+
+// PageScreenshotFull takes a screenshot of the whole current page with options o and returns it as decoded image.
+//
+// The document is scrolled in viewport sized steps via script, every step is captured and the tiles are stitched together.
+// The last tile is cropped to the page height. The image scale follows the device pixel ratio of the screenshots.
+// The original scroll position is restored afterwards.
+func (c *Client) PageScreenshotFull(ctx context.Context, o FullPageOptions) (image.Image, error) {
+	m, err := ExecuteScript[fullPageMetrics](ctx, c, fullPageMetricsScript)
+	if err != nil {
+		return nil, err
+	}
+	if m.ViewWidth <= 0 || m.ViewHeight <= 0 {
+		return nil, errors.New("viewport is empty")
+	}
+
+	height := m.Height
+	if o.MaxHeight > 0 && float64(o.MaxHeight) < height {
+		height = float64(o.MaxHeight)
+	}
+
+	img, err := c.pageScreenshotTiles(ctx, o, m, height)
+
+	rerr := c.restorePageScroll(ctx, o, m)
+	err = withRestore(err, rerr)
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// pageScreenshotTiles captures tiles of the page up to height and stitches them together.
+func (c *Client) pageScreenshotTiles(ctx context.Context, o FullPageOptions, m fullPageMetrics, height float64) (image.Image, error) {
+	var canvas *image.RGBA
+	var scale float64
+
+	for y := 0.0; y < height; y += m.ViewHeight {
+		if o.HideFixed && y == m.ViewHeight {
+			_, err := c.PageScript(ctx, fullPageHideFixedScript, []interface{}{true})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		top, err := ExecuteScript[float64](ctx, c, fullPageScrollScript, 0, y)
+		if err != nil {
+			return nil, err
+		}
+
+		if o.Delay > 0 {
+			time.Sleep(o.Delay)
+		}
+
+		tile, err := c.PageScreenshotImage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if canvas == nil {
+			// device pixel ratio is taken from the screenshot itself, it's more reliable than the reported one
+			scale = float64(tile.Bounds().Dx()) / m.ViewWidth
+			canvas = image.NewRGBA(image.Rect(0, 0, tile.Bounds().Dx(), int(math.Ceil(height*scale))))
+		}
+
+		// the final tile can't be scrolled further than the page end, so its upper part may overlap the previous one
+		src := image.Pt(tile.Bounds().Min.X, tile.Bounds().Min.Y+int(math.Round((y-top)*scale)))
+		dst := image.Rect(0, int(math.Round(y*scale)), canvas.Bounds().Dx(), int(math.Round(math.Min(y+m.ViewHeight, height)*scale)))
+
+		draw.Draw(canvas, dst, tile, src, draw.Src)
+	}
+
+	if canvas == nil {
+		return nil, errors.New("page is empty")
+	}
+
+	return canvas, nil
+}
+
+// restorePageScroll restores the scroll position and fixed elements of the page changed by a full page screenshot.
+func (c *Client) restorePageScroll(ctx context.Context, o FullPageOptions, m fullPageMetrics) error {
+	if o.HideFixed {
+		_, err := c.PageScript(ctx, fullPageHideFixedScript, []interface{}{false})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := c.PageScript(ctx, fullPageScrollScript, []interface{}{m.ScrollX, m.ScrollY})
+
+	return err
+}
+
+// PageScreenshotPNG command is used to take a screenshot of the current page and return it as PNG encoded bytes.
+//
+// https://www.w3.org/TR/webdriver/#take-screenshot
+func (c *Client) PageScreenshotPNG(ctx context.Context) ([]byte, error) {
+	route := fmt.Sprintf("session/%s/screenshot", c.session.ID)
+
+	return c.screenshotPNG(ctx, route)
+}
+
+// PageScreenshotImage command is used to take a screenshot of the current page and return it as decoded image.
+//
+// https://www.w3.org/TR/webdriver/#take-screenshot
+func (c *Client) PageScreenshotImage(ctx context.Context) (image.Image, error) {
+	route := fmt.Sprintf("session/%s/screenshot", c.session.ID)
+
+	return c.screenshotImage(ctx, route)
+}
+
+// PageScreenshotSave command is used to take a screenshot of the current page and save it as PNG file with path p.
+//
+// https://www.w3.org/TR/webdriver/#take-screenshot
+func (c *Client) PageScreenshotSave(ctx context.Context, p string) error {
+	route := fmt.Sprintf("session/%s/screenshot", c.session.ID)
+
+	return c.screenshotSave(ctx, route, p)
+}
+
+// ElementScreenshotPNG command is used to take a screenshot of an element e and return it as PNG encoded bytes.
+//
+// https://www.w3.org/TR/webdriver/#take-element-screenshot
+func (c *Client) ElementScreenshotPNG(ctx context.Context, e WebElement) ([]byte, error) {
+	if e.Reference == "" {
+		return nil, errors.New("element ID is empty")
+	}
+	if !e.isElement() {
+		return nil, errors.New("reference is not a web element")
+	}
+
+	route := fmt.Sprintf("session/%s/element/%s/screenshot", c.session.ID, e.Reference)
+
+	return c.screenshotPNG(ctx, route)
+}
+
+// ElementScreenshotImage command is used to take a screenshot of an element e and return it as decoded image.
+//
+// https://www.w3.org/TR/webdriver/#take-element-screenshot
+func (c *Client) ElementScreenshotImage(ctx context.Context, e WebElement) (image.Image, error) {
+	if e.Reference == "" {
+		return nil, errors.New("element ID is empty")
+	}
+	if !e.isElement() {
+		return nil, errors.New("reference is not a web element")
+	}
+
+	route := fmt.Sprintf("session/%s/element/%s/screenshot", c.session.ID, e.Reference)
+
+	return c.screenshotImage(ctx, route)
+}
+
+// ElementScreenshotSave command is used to take a screenshot of an element e and save it as PNG file with path p.
+//
+// https://www.w3.org/TR/webdriver/#take-element-screenshot
+func (c *Client) ElementScreenshotSave(ctx context.Context, e WebElement, p string) error {
+	if e.Reference == "" {
+		return errors.New("element ID is empty")
+	}
+	if !e.isElement() {
+		return errors.New("reference is not a web element")
+	}
+
+	route := fmt.Sprintf("session/%s/element/%s/screenshot", c.session.ID, e.Reference)
+
+	return c.screenshotSave(ctx, route, p)
+}
+
+// screenshotPNG requests a screenshot with route and returns PNG encoded bytes.
+func (c *Client) screenshotPNG(ctx context.Context, route string) ([]byte, error) {
+	b := new(bytes.Buffer)
+
+	err := c.base64Value(ctx, http.MethodGet, route, nil, func(r io.Reader) error {
+		_, err := io.Copy(b, r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// screenshotImage requests a screenshot with route and returns decoded image.
+func (c *Client) screenshotImage(ctx context.Context, route string) (image.Image, error) {
+	var img image.Image
+
+	err := c.base64Value(ctx, http.MethodGet, route, nil, func(r io.Reader) error {
+		var err error
+		img, err = png.Decode(r)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
+// screenshotSave requests a screenshot with route and saves it to file with path p.
+func (c *Client) screenshotSave(ctx context.Context, route string, p string) error {
+	if p == "" {
+		return errors.New("file path is empty")
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+
+	err = c.base64Value(ctx, http.MethodGet, route, nil, func(r io.Reader) error {
+		_, err := io.Copy(f, r)
+		return err
+	})
+	if err != nil {
+		_ = f.Close()
+		_ = os.Remove(p)
+		return err
+	}
+
+	return f.Close()
+}
+
+// base64Value sends a server request and passes a decoding reader of the base64 encoded response value to fn.
+//
+// The value is decoded while it's read from the response body, so large values are never held in memory as a whole.
+func (c *Client) base64Value(ctx context.Context, method, route string, body io.Reader, fn func(r io.Reader) error) error {
+	req, err := c.prepare(method, route, body)
+	if err != nil {
+		return err
+	}
+
+	return c.send(ctx, req, func(body io.Reader) error {
+		r, err := stringValueReader(body)
+		if err != nil {
+			return err
+		}
+
+		return fn(base64.NewDecoder(base64.StdEncoding, r))
+	})
+}
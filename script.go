@@ -0,0 +1,244 @@
+package wdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+//
+// ERRORS
+//
+
+var (
+	// ErrorScriptNull is returned when a script returns null and the result type can't hold it.
+	ErrorScriptNull = errors.New("script returned null")
+	// ErrorScriptUndefined is returned when a script returns undefined or nothing at all.
+	ErrorScriptUndefined = errors.New("script returned undefined")
+)
+
+// scriptUndefinedKey marks an undefined script result, because the server serializes undefined as null.
+const scriptUndefinedKey = "wdc:undefined"
+
+// scriptWrapper runs a script as a function and replaces an undefined result with a marker object.
+const scriptWrapper = `var r = (function() {
+%s
+}).apply(this, arguments);
+return r === undefined ? {%q: true} : r;`
+
+//
+// FUNCTIONS
+//
+
+// ExecuteScript runs a snippet of JavaScript s with arguments args in the currently selected frame of client c and decodes the result into type T.
+//
+// The script is assumed to be synchronous, the legacy endpoint is used if the server speaks the legacy protocol. Web element, shadow root, window and frame references, also nested in arrays and objects, are converted into WebElement
+// both for typed fields and for values decoded into interface{}. A null result yields the zero value of T if T is a pointer, interface, slice or map,
+// otherwise ErrorScriptNull is returned. An undefined result always yields ErrorScriptUndefined.
+// https://www.w3.org/TR/webdriver/#execute-script
+func ExecuteScript[T any](ctx context.Context, c *Client, s string, args ...interface{}) (T, error) {
+	var v T
+
+	if c == nil {
+		return v, errors.New("client is empty")
+	}
+	if s == "" {
+		return v, errors.New("script is empty")
+	}
+
+	legacy, err := c.legacy(ctx)
+	if err != nil {
+		return v, err
+	}
+
+	command := "execute/sync"
+	if legacy {
+		command = "execute"
+	}
+
+	raw, err := c.executeScript(ctx, command, fmt.Sprintf(scriptWrapper, s, scriptUndefinedKey), args)
+	if err != nil {
+		return v, err
+	}
+
+	err = decodeScriptResult(raw, &v)
+	if err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+//
+// METHODS
+//
+
+// ScriptElement command is used to run a snippet of JavaScript s with arguments args which returns a node, e.g. an element or a shadow root.
+//
+// If the script returns null or undefined, ErrorNoSuchElement is returned.
+// https://www.w3.org/TR/webdriver/#execute-script
+func (c *Client) ScriptElement(ctx context.Context, s string, args []interface{}) (WebElement, error) {
+	e, err := ExecuteScript[WebElement](ctx, c, s, args...)
+	if errors.Is(err, ErrorScriptNull) || errors.Is(err, ErrorScriptUndefined) {
+		return WebElement{}, ErrorNoSuchElement
+	}
+	if err != nil {
+		return WebElement{}, err
+	}
+
+	return e, nil
+}
+
+// ScriptElements command is used to run a snippet of JavaScript s with arguments args which returns a list of nodes, e.g. an array or a NodeList.
+//
+// Null items of the list, e.g. results of querySelector which found nothing, are skipped.
+// If the script returns null, undefined or a list without nodes, ErrorNoSuchElement is returned.
+// https://www.w3.org/TR/webdriver/#execute-script
+func (c *Client) ScriptElements(ctx context.Context, s string, args []interface{}) ([]WebElement, error) {
+	res, err := ExecuteScript[[]WebElement](ctx, c, s, args...)
+	if errors.Is(err, ErrorScriptUndefined) {
+		return nil, ErrorNoSuchElement
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var elems []WebElement
+	for _, e := range res {
+		if e.Reference != "" {
+			elems = append(elems, e)
+		}
+	}
+
+	if len(elems) == 0 {
+		return nil, ErrorNoSuchElement
+	}
+
+	return elems, nil
+}
+
+// executeScript sends script s with arguments args to the execute endpoint command and returns the raw result.
+func (c *Client) executeScript(ctx context.Context, command string, s string, args []interface{}) (json.RawMessage, error) {
+	r := &scriptRequest{Script: s, Args: prepareScriptArguments(args)}
+
+	b := new(bytes.Buffer)
+	err := json.NewEncoder(b).Encode(r)
+	if err != nil {
+		return nil, err
+	}
+
+	route := fmt.Sprintf("session/%s/%s", c.session.ID, command)
+
+	req, err := c.prepare(http.MethodPost, route, b)
+	if err != nil {
+		return nil, err
+	}
+
+	res := new(rawValue)
+
+	err = c.do(ctx, req, res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Value, nil
+}
+
+//
+// UTILS
+//
+
+// decodeScriptResult decodes raw script result into v which must be a non-nil pointer.
+func decodeScriptResult(raw json.RawMessage, v interface{}) error {
+	raw = bytes.TrimSpace(raw)
+
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		switch reflect.TypeOf(v).Elem().Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+			return nil
+		}
+		return ErrorScriptNull
+	}
+
+	var marker map[string]json.RawMessage
+	if json.Unmarshal(raw, &marker) == nil && len(marker) == 1 {
+		if _, ok := marker[scriptUndefinedKey]; ok {
+			return ErrorScriptUndefined
+		}
+	}
+
+	err := json.Unmarshal(raw, v)
+	if err != nil {
+		return err
+	}
+
+	convertElements(reflect.ValueOf(v).Elem())
+
+	return nil
+}
+
+// convertElements replaces remote object reference objects stored in interface values of v with WebElement.
+func convertElements(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		e := reflect.ValueOf(elementsOf(v.Elem().Interface()))
+		if e.Type().AssignableTo(v.Type()) {
+			v.Set(e)
+		}
+	case reflect.Ptr:
+		if !v.IsNil() {
+			convertElements(v.Elem())
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				convertElements(f)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			convertElements(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			e := reflect.New(v.Type().Elem()).Elem()
+			e.Set(iter.Value())
+			convertElements(e)
+			v.SetMapIndex(iter.Key(), e)
+		}
+	}
+}
+
+// elementsOf converts web element, shadow root, window and frame reference objects within a decoded JSON value x into WebElement.
+func elementsOf(x interface{}) interface{} {
+	switch v := x.(type) {
+	case map[string]interface{}:
+		for _, id := range webElementIDs {
+			if ref, ok := v[string(id)].(string); ok {
+				return WebElement{ID: id, Reference: WebElementReference(ref)}
+			}
+		}
+		if ref, ok := v[string(WebElementIDLegacy)].(string); ok && len(v) == 1 {
+			return WebElement{ID: WebElementIDLegacy, Reference: WebElementReference(ref)}
+		}
+		for k, e := range v {
+			v[k] = elementsOf(e)
+		}
+		return v
+	case []interface{}:
+		for i, e := range v {
+			v[i] = elementsOf(e)
+		}
+		return v
+	default:
+		return x
+	}
+}
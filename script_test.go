@@ -0,0 +1,205 @@
+package wdc
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// errAny matches any non-nil error in test tables.
+var errAny = errors.New("any error")
+
+func TestDecodeScriptResult(t *testing.T) {
+	w3c := func(ref string) WebElement {
+		return WebElement{ID: WebElementIDW3C, Reference: WebElementReference(ref)}
+	}
+	legacy := func(ref string) WebElement {
+		return WebElement{ID: WebElementIDLegacy, Reference: WebElementReference(ref)}
+	}
+
+	type typed struct {
+		Elem  WebElement             `json:"elem"`
+		List  []interface{}          `json:"list"`
+		Map   map[string]interface{} `json:"map"`
+		Count int                    `json:"count"`
+	}
+
+	tests := []struct {
+		name string
+		raw  string
+		dst  func() interface{}
+		want interface{}
+		err  error
+	}{
+		{
+			name: "null into pointer",
+			raw:  `null`,
+			dst:  func() interface{} { return new(*string) },
+			want: (*string)(nil),
+		},
+		{
+			name: "null into slice",
+			raw:  `null`,
+			dst:  func() interface{} { return new([]int) },
+			want: []int(nil),
+		},
+		{
+			name: "null into interface",
+			raw:  ` null `,
+			dst:  func() interface{} { return new(interface{}) },
+			want: nil,
+		},
+		{
+			name: "null into int",
+			raw:  `null`,
+			dst:  func() interface{} { return new(int) },
+			err:  ErrorScriptNull,
+		},
+		{
+			name: "undefined",
+			raw:  `{"wdc:undefined":true}`,
+			dst:  func() interface{} { return new(interface{}) },
+			err:  ErrorScriptUndefined,
+		},
+		{
+			name: "undefined marker with other keys is an object",
+			raw:  `{"wdc:undefined":true,"a":1}`,
+			dst:  func() interface{} { return new(map[string]interface{}) },
+			want: map[string]interface{}{"wdc:undefined": true, "a": float64(1)},
+		},
+		{
+			name: "number",
+			raw:  `42`,
+			dst:  func() interface{} { return new(int) },
+			want: 42,
+		},
+		{
+			name: "W3C element into interface",
+			raw:  `{"element-6066-11e4-a52e-4f735466cecf":"e1"}`,
+			dst:  func() interface{} { return new(interface{}) },
+			want: w3c("e1"),
+		},
+		{
+			name: "legacy element into interface",
+			raw:  `{"ELEMENT":"e1"}`,
+			dst:  func() interface{} { return new(interface{}) },
+			want: legacy("e1"),
+		},
+		{
+			name: "W3C key takes precedence over legacy",
+			raw:  `{"ELEMENT":"e1","element-6066-11e4-a52e-4f735466cecf":"e2"}`,
+			dst:  func() interface{} { return new(interface{}) },
+			want: w3c("e2"),
+		},
+		{
+			name: "legacy key with other keys is an object",
+			raw:  `{"ELEMENT":"e1","other":"x"}`,
+			dst:  func() interface{} { return new(interface{}) },
+			want: map[string]interface{}{"ELEMENT": "e1", "other": "x"},
+		},
+		{
+			name: "shadow root into interface",
+			raw:  `{"shadow-6066-11e4-a52e-4f735466cecf":"s1"}`,
+			dst:  func() interface{} { return new(interface{}) },
+			want: WebElement{ID: WebElementIDShadowRoot, Reference: "s1"},
+		},
+		{
+			name: "nested in arrays and objects",
+			raw:  `{"a":[{"element-6066-11e4-a52e-4f735466cecf":"e1"},[{"ELEMENT":"e2"}]],"b":{"c":{"ELEMENT":"e3"}},"d":"text"}`,
+			dst:  func() interface{} { return new(interface{}) },
+			want: map[string]interface{}{
+				"a": []interface{}{w3c("e1"), []interface{}{legacy("e2")}},
+				"b": map[string]interface{}{"c": legacy("e3")},
+				"d": "text",
+			},
+		},
+		{
+			name: "array into slice of interfaces",
+			raw:  `[{"ELEMENT":"e1"},1,"x"]`,
+			dst:  func() interface{} { return new([]interface{}) },
+			want: []interface{}{legacy("e1"), float64(1), "x"},
+		},
+		{
+			name: "map values",
+			raw:  `{"k":{"element-6066-11e4-a52e-4f735466cecf":"e1"},"n":null}`,
+			dst:  func() interface{} { return new(map[string]interface{}) },
+			want: map[string]interface{}{"k": w3c("e1"), "n": nil},
+		},
+		{
+			name: "array into slice of elements",
+			raw:  `[{"element-6066-11e4-a52e-4f735466cecf":"e1"},{"ELEMENT":"e2"}]`,
+			dst:  func() interface{} { return new([]WebElement) },
+			want: []WebElement{w3c("e1"), legacy("e2")},
+		},
+		{
+			name: "nulls in slice of elements",
+			raw:  `[null,{"element-6066-11e4-a52e-4f735466cecf":"e1"},null]`,
+			dst:  func() interface{} { return new([]WebElement) },
+			want: []WebElement{{}, w3c("e1"), {}},
+		},
+		{
+			name: "null element into struct field",
+			raw:  `{"elem":null,"count":1}`,
+			dst:  func() interface{} { return new(typed) },
+			want: typed{Count: 1},
+		},
+		{
+			name: "null into element pointer",
+			raw:  `null`,
+			dst:  func() interface{} { return new(*WebElement) },
+			want: (*WebElement)(nil),
+		},
+		{
+			name: "null into element",
+			raw:  `null`,
+			dst:  func() interface{} { return new(WebElement) },
+			err:  ErrorScriptNull,
+		},
+		{
+			name: "object into element",
+			raw:  `{"a":1}`,
+			dst:  func() interface{} { return new(WebElement) },
+			err:  errAny,
+		},
+		{
+			name: "typed struct fields",
+			raw:  `{"elem":{"ELEMENT":"e1"},"list":[{"element-6066-11e4-a52e-4f735466cecf":"e2"}],"map":{"k":{"ELEMENT":"e3"}},"count":2}`,
+			dst:  func() interface{} { return new(typed) },
+			want: typed{
+				Elem:  legacy("e1"),
+				List:  []interface{}{w3c("e2")},
+				Map:   map[string]interface{}{"k": legacy("e3")},
+				Count: 2,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := tt.dst()
+
+			err := decodeScriptResult(json.RawMessage(tt.raw), dst)
+			if tt.err == errAny {
+				if err == nil {
+					t.Fatal("got no error, want one")
+				}
+				return
+			}
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("got error %v, want %v", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := reflect.ValueOf(dst).Elem().Interface()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package wdc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+//
+// TYPES
+//
+
+// State is a snapshot of a browser session state which can be saved with SaveState and restored with LoadState.
+//
+// It's serialized as JSON in the following format:
+//
+//	{
+//	  "url": "https://example.com/account",
+//	  "origins": [
+//	    {
+//	      "origin": "https://example.com",
+//	      "cookies": [{"name": "sid", "value": "...", "path": "/", "domain": "example.com", ...}],
+//	      "localStorage": {"key": "value"},
+//	      "sessionStorage": {"key": "value"}
+//	    }
+//	  ]
+//	}
+type State struct {
+	// URL is the page URL which was active when the state was saved.
+	URL string `json:"url"`
+	// Origins lists the state of every saved origin.
+	Origins []OriginState `json:"origins"`
+}
+
+// OriginState is a state of a single origin, i.e. scheme, host and port of a page.
+type OriginState struct {
+	Origin         string            `json:"origin"`
+	Cookies        []Cookie          `json:"cookies"`
+	LocalStorage   map[string]string `json:"localStorage"`
+	SessionStorage map[string]string `json:"sessionStorage"`
+}
+
+//
+// METHODS
+//
+
+// SaveState writes the state of the current page origin and of additional origins to w as JSON, see State for the format.
+//
+// To read the state of additional origins the browser navigates to each of them and then back to the current URL.
+func (c *Client) SaveState(ctx context.Context, w io.Writer, origins ...string) error {
+	if w == nil {
+		return errors.New("writer is empty")
+	}
+
+	cur, err := c.PageURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	s := State{URL: cur}
+
+	o, err := c.originState(ctx)
+	if err != nil {
+		return err
+	}
+	if o.Origin != "" {
+		s.Origins = append(s.Origins, o)
+	}
+
+	for _, origin := range origins {
+		err = c.NavigateTo(ctx, strings.TrimSuffix(origin, "/")+"/")
+		if err != nil {
+			return err
+		}
+
+		o, err = c.originState(ctx)
+		if err != nil {
+			return err
+		}
+		if o.Origin != "" {
+			s.Origins = append(s.Origins, o)
+		}
+	}
+
+	if len(origins) > 0 {
+		err = c.NavigateTo(ctx, cur)
+		if err != nil {
+			return err
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(s)
+}
+
+// LoadState reads a state saved with SaveState from r and restores it.
+//
+// The browser navigates to every saved origin to restore its cookies and web storage, and finally to the saved URL.
+func (c *Client) LoadState(ctx context.Context, r io.Reader) error {
+	if r == nil {
+		return errors.New("reader is empty")
+	}
+
+	s := State{}
+
+	err := json.NewDecoder(r).Decode(&s)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range s.Origins {
+		err = c.loadOriginState(ctx, o)
+		if err != nil {
+			return fmt.Errorf("origin %s: %w", o.Origin, err)
+		}
+	}
+
+	if s.URL != "" {
+		return c.NavigateTo(ctx, s.URL)
+	}
+
+	return nil
+}
+
+// originState reads the state of the current page origin. Pages with an opaque origin, e.g. about:blank, have an empty state.
+func (c *Client) originState(ctx context.Context) (OriginState, error) {
+	origin, err := ExecuteScript[string](ctx, c, "return window.location.origin")
+	if err != nil {
+		return OriginState{}, err
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return OriginState{}, nil
+	}
+
+	o := OriginState{Origin: origin}
+
+	o.Cookies, err = c.Cookies(ctx)
+	if err != nil {
+		return OriginState{}, err
+	}
+
+	o.LocalStorage, err = c.LocalStorage().All(ctx)
+	if err != nil {
+		return OriginState{}, err
+	}
+
+	o.SessionStorage, err = c.SessionStorage().All(ctx)
+	if err != nil {
+		return OriginState{}, err
+	}
+
+	return o, nil
+}
+
+// loadOriginState navigates to origin o and restores its cookies and web storage.
+func (c *Client) loadOriginState(ctx context.Context, o OriginState) error {
+	if o.Origin == "" {
+		return errors.New("origin is empty")
+	}
+
+	err := c.NavigateTo(ctx, strings.TrimSuffix(o.Origin, "/")+"/")
+	if err != nil {
+		return err
+	}
+
+	for _, v := range o.Cookies {
+		err = c.CookieSet(ctx, v)
+		if err != nil {
+			return fmt.Errorf("cookie %s: %w", v.Name, err)
+		}
+	}
+
+	for k, v := range o.LocalStorage {
+		err = c.LocalStorage().Set(ctx, k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	for k, v := range o.SessionStorage {
+		err = c.SessionStorage().Set(ctx, k, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package wdc
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -14,8 +16,10 @@ type StatusValue struct {
 	Ready   bool        `json:"ready"`
 	Message string      `json:"message"`
 	Build   StatusBuild `json:"build"`
-	OS      StatusBuild `json:"os"`
+	OS      StatusOS    `json:"os"`
 	Java    StatusJava  `json:"java"`
+	// Nodes lists the nodes of a Selenium Grid 4 server.
+	Nodes []StatusNode `json:"nodes"`
 }
 
 type StatusBuild struct {
@@ -34,6 +38,53 @@ type StatusJava struct {
 	Java string `json:"java"`
 }
 
+// StatusAvailability is an availability of a Selenium Grid node.
+type StatusAvailability string
+
+const (
+	StatusUp       StatusAvailability = "UP"
+	StatusDraining StatusAvailability = "DRAINING"
+	StatusDown     StatusAvailability = "DOWN"
+)
+
+// StatusNode is a node of a Selenium Grid 4 server.
+type StatusNode struct {
+	ID           string             `json:"id"`
+	URI          string             `json:"uri"`
+	Availability StatusAvailability `json:"availability"`
+	Version      string             `json:"version"`
+	OS           StatusOS           `json:"osInfo"`
+	// MaxSessions is the maximum number of sessions the node runs concurrently.
+	MaxSessions int `json:"maxSessions"`
+	// HeartbeatPeriod is the heartbeat period of the node in milliseconds.
+	HeartbeatPeriod int          `json:"heartbeatPeriod"`
+	Slots           []StatusSlot `json:"slots"`
+}
+
+// StatusSlot is a slot of a Selenium Grid node which can run a single session with the capabilities of its stereotype.
+type StatusSlot struct {
+	ID          StatusSlotID           `json:"id"`
+	LastStarted time.Time              `json:"lastStarted"`
+	Stereotype  map[string]interface{} `json:"stereotype"`
+	// Session is the session running in the slot, nil if the slot is free.
+	Session *StatusSession `json:"session"`
+}
+
+// StatusSlotID identifies a slot within a Selenium Grid.
+type StatusSlotID struct {
+	HostID string `json:"hostId"`
+	ID     string `json:"id"`
+}
+
+// StatusSession is a session running in a Selenium Grid slot.
+type StatusSession struct {
+	ID           string                 `json:"sessionId"`
+	URI          string                 `json:"uri"`
+	Start        time.Time              `json:"start"`
+	Capabilities map[string]interface{} `json:"capabilities"`
+	Stereotype   map[string]interface{} `json:"stereotype"`
+}
+
 //
 // RESPONSES
 //
@@ -65,3 +116,113 @@ func (c *Client) Status(ctx context.Context) (Status, error) {
 
 	return *res, nil
 }
+
+// WaitUntilReady sets interval i and amount of time t the driver should wait until the server reports it's ready to create new sessions.
+//
+// Errors of the status command, e.g. while the server is starting, are retried until the time is out.
+func (c *Client) WaitUntilReady(ctx context.Context, i time.Duration, t time.Duration) error {
+	start := time.Now()
+
+	for {
+		s, err := c.Status(ctx)
+		if err == nil && s.Value.Ready {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if elapsed := time.Since(start); elapsed > t {
+			if err != nil {
+				return fmt.Errorf("timeout after %v: %w", elapsed, err)
+			}
+			return fmt.Errorf("timeout after %v", elapsed)
+		}
+		time.Sleep(i)
+	}
+}
+
+// Sessions returns the sessions running on all nodes of a Selenium Grid 4 server.
+func (s StatusValue) Sessions() []StatusSession {
+	var res []StatusSession
+	for _, n := range s.Nodes {
+		for _, sl := range n.Slots {
+			if sl.Session != nil {
+				res = append(res, *sl.Session)
+			}
+		}
+	}
+	return res
+}
+
+// MaxConcurrency returns the maximum number of sessions the available nodes of a Selenium Grid 4 server run concurrently.
+func (s StatusValue) MaxConcurrency() int {
+	total := 0
+	for _, n := range s.Nodes {
+		if n.Availability == StatusUp {
+			total += n.MaxSessions
+		}
+	}
+	return total
+}
+
+// FreeSlots returns the free slots of the available nodes of a Selenium Grid 4 server which can run a session with capabilities caps.
+//
+// A slot matches if its stereotype has every capability of caps with an equal value. Browser and platform names are compared case-insensitively.
+// Slots of nodes which already run their maximum number of sessions are not free.
+func (s StatusValue) FreeSlots(caps map[string]interface{}) []StatusSlot {
+	var res []StatusSlot
+	for _, n := range s.Nodes {
+		if n.Availability != StatusUp {
+			continue
+		}
+
+		busy := 0
+		for _, sl := range n.Slots {
+			if sl.Session != nil {
+				busy++
+			}
+		}
+
+		free := n.MaxSessions - busy
+		if n.MaxSessions == 0 {
+			free = len(n.Slots) - busy
+		}
+		for _, sl := range n.Slots {
+			if free <= 0 {
+				break
+			}
+			if sl.Session == nil && stereotypeMatches(sl.Stereotype, caps) {
+				res = append(res, sl)
+				free--
+			}
+		}
+	}
+	return res
+}
+
+// stereotypeMatches reports whether slot stereotype st satisfies capabilities caps.
+func stereotypeMatches(st, caps map[string]interface{}) bool {
+	for k, want := range caps {
+		got, ok := st[k]
+		if !ok {
+			return false
+		}
+
+		if k == "browserName" || k == "platformName" {
+			ws, wok := want.(string)
+			gs, gok := got.(string)
+			if wok && gok && strings.EqualFold(ws, gs) {
+				continue
+			}
+			if wok && gok && k == "platformName" && strings.EqualFold(ws, "any") {
+				continue
+			}
+		}
+
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			return false
+		}
+	}
+	return true
+}
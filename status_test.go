@@ -0,0 +1,271 @@
+package wdc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// gridStatus is a /status response of a Selenium Grid 4 hub with one node running one of its two sessions.
+const gridStatus = `{
+  "value": {
+    "ready": true,
+    "message": "Selenium Grid ready.",
+    "nodes": [
+      {
+        "id": "9a3d2f1e-node",
+        "uri": "http://172.18.0.3:5555",
+        "maxSessions": 2,
+        "osInfo": {
+          "arch": "amd64",
+          "name": "Linux",
+          "version": "5.15.0-91-generic"
+        },
+        "heartbeatPeriod": 60000,
+        "availability": "UP",
+        "version": "4.16.1 (revision 9b4c83354e)",
+        "slots": [
+          {
+            "id": {
+              "hostId": "9a3d2f1e-node",
+              "id": "slot-1"
+            },
+            "lastStarted": "2024-01-10T12:00:00.123Z",
+            "session": {
+              "capabilities": {
+                "browserName": "chrome",
+                "browserVersion": "120.0.6099.109",
+                "platformName": "linux"
+              },
+              "sessionId": "f0c4a1b2",
+              "start": "2024-01-10T12:00:00.456Z",
+              "stereotype": {
+                "browserName": "chrome",
+                "platformName": "linux"
+              },
+              "uri": "http://172.18.0.3:5555"
+            },
+            "stereotype": {
+              "browserName": "chrome",
+              "browserVersion": "120.0",
+              "platformName": "linux",
+              "se:noVncPort": 7900
+            }
+          },
+          {
+            "id": {
+              "hostId": "9a3d2f1e-node",
+              "id": "slot-2"
+            },
+            "lastStarted": "1970-01-01T00:00:00Z",
+            "session": null,
+            "stereotype": {
+              "browserName": "chrome",
+              "browserVersion": "120.0",
+              "platformName": "linux",
+              "se:noVncPort": 7900
+            }
+          }
+        ]
+      }
+    ]
+  }
+}`
+
+func TestStatusDecodeGrid(t *testing.T) {
+	var s Status
+
+	err := json.Unmarshal([]byte(gridStatus), &s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := s.Value
+	if !v.Ready || v.Message != "Selenium Grid ready." || len(v.Nodes) != 1 {
+		t.Fatalf("got %+v, want a ready grid with one node", v)
+	}
+
+	n := v.Nodes[0]
+	if n.ID != "9a3d2f1e-node" || n.URI != "http://172.18.0.3:5555" || n.Availability != StatusUp ||
+		n.MaxSessions != 2 || n.HeartbeatPeriod != 60000 || n.Version != "4.16.1 (revision 9b4c83354e)" {
+		t.Errorf("got node %+v", n)
+	}
+	if want := (StatusOS{Arch: "amd64", Name: "Linux", Version: "5.15.0-91-generic"}); n.OS != want {
+		t.Errorf("got OS %+v, want %+v", n.OS, want)
+	}
+	if len(n.Slots) != 2 {
+		t.Fatalf("got %d slots, want 2", len(n.Slots))
+	}
+
+	busy, free := n.Slots[0], n.Slots[1]
+	if want := (StatusSlotID{HostID: "9a3d2f1e-node", ID: "slot-1"}); busy.ID != want {
+		t.Errorf("got slot ID %+v, want %+v", busy.ID, want)
+	}
+	if want := time.Date(2024, 1, 10, 12, 0, 0, 123e6, time.UTC); !busy.LastStarted.Equal(want) {
+		t.Errorf("got last started %v, want %v", busy.LastStarted, want)
+	}
+	if busy.Stereotype["se:noVncPort"] != float64(7900) {
+		t.Errorf("got stereotype %v", busy.Stereotype)
+	}
+	if busy.Session == nil || busy.Session.ID != "f0c4a1b2" || busy.Session.Capabilities["browserVersion"] != "120.0.6099.109" {
+		t.Fatalf("got session %+v", busy.Session)
+	}
+	if want := time.Date(2024, 1, 10, 12, 0, 0, 456e6, time.UTC); !busy.Session.Start.Equal(want) {
+		t.Errorf("got session start %v, want %v", busy.Session.Start, want)
+	}
+	if free.Session != nil || !free.LastStarted.Equal(time.Unix(0, 0)) {
+		t.Errorf("got free slot %+v", free)
+	}
+
+	if got := v.Sessions(); len(got) != 1 || got[0].ID != "f0c4a1b2" {
+		t.Errorf("got sessions %+v", got)
+	}
+	if got := v.MaxConcurrency(); got != 2 {
+		t.Errorf("got max concurrency %d, want 2", got)
+	}
+	if got := v.FreeSlots(map[string]interface{}{"browserName": "Chrome"}); len(got) != 1 || got[0].ID.ID != "slot-2" {
+		t.Errorf("got free slots %+v", got)
+	}
+}
+
+func TestStatusFreeSlots(t *testing.T) {
+	chrome := map[string]interface{}{"browserName": "chrome", "platformName": "LINUX", "se:noVncPort": float64(7900)}
+	firefox := map[string]interface{}{"browserName": "firefox", "platformName": "linux"}
+
+	slot := func(id string, st map[string]interface{}, busy bool) StatusSlot {
+		sl := StatusSlot{ID: StatusSlotID{ID: id}, Stereotype: st}
+		if busy {
+			sl.Session = &StatusSession{ID: "s-" + id}
+		}
+		return sl
+	}
+
+	tests := []struct {
+		name  string
+		nodes []StatusNode
+		caps  map[string]interface{}
+		want  []string
+	}{
+		{
+			name: "all free slots without capabilities",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 2, Slots: []StatusSlot{slot("a", chrome, false), slot("b", firefox, false)}},
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "busy slots are skipped",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 2, Slots: []StatusSlot{slot("a", chrome, true), slot("b", chrome, false)}},
+			},
+			want: []string{"b"},
+		},
+		{
+			name: "node at max sessions",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("a", chrome, true), slot("b", firefox, false)}},
+			},
+		},
+		{
+			name: "max sessions limits free slots",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 2, Slots: []StatusSlot{slot("a", chrome, true), slot("b", chrome, false), slot("c", chrome, false)}},
+			},
+			want: []string{"b"},
+		},
+		{
+			name: "missing max sessions falls back to slot count",
+			nodes: []StatusNode{
+				{Availability: StatusUp, Slots: []StatusSlot{slot("a", chrome, true), slot("b", chrome, false), slot("c", chrome, false)}},
+			},
+			want: []string{"b", "c"},
+		},
+		{
+			name: "unavailable nodes",
+			nodes: []StatusNode{
+				{Availability: StatusDraining, MaxSessions: 1, Slots: []StatusSlot{slot("a", chrome, false)}},
+				{Availability: StatusDown, MaxSessions: 1, Slots: []StatusSlot{slot("b", chrome, false)}},
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("c", chrome, false)}},
+			},
+			want: []string{"c"},
+		},
+		{
+			name: "browser name is case-insensitive",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 2, Slots: []StatusSlot{slot("a", chrome, false), slot("b", firefox, false)}},
+			},
+			caps: map[string]interface{}{"browserName": "Chrome"},
+			want: []string{"a"},
+		},
+		{
+			name: "platform name is case-insensitive",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 2, Slots: []StatusSlot{slot("a", chrome, false), slot("b", firefox, false)}},
+			},
+			caps: map[string]interface{}{"platformName": "linux"},
+			want: []string{"a", "b"},
+		},
+		{
+			name: "platform any",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 2, Slots: []StatusSlot{slot("a", chrome, false), slot("b", firefox, false)}},
+			},
+			caps: map[string]interface{}{"browserName": "firefox", "platformName": "ANY"},
+			want: []string{"b"},
+		},
+		{
+			name: "other capabilities are case-sensitive",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("a", map[string]interface{}{"se:name": "grid"}, false)}},
+			},
+			caps: map[string]interface{}{"se:name": "Grid"},
+		},
+		{
+			name: "values compared by their text",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("a", chrome, false)}},
+			},
+			caps: map[string]interface{}{"se:noVncPort": 7900},
+			want: []string{"a"},
+		},
+		{
+			name: "different value",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("a", chrome, false)}},
+			},
+			caps: map[string]interface{}{"se:noVncPort": 5900},
+		},
+		{
+			name: "capability missing in stereotype",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("a", firefox, false)}},
+			},
+			caps: map[string]interface{}{"browserVersion": "120"},
+		},
+		{
+			name: "slots of several nodes",
+			nodes: []StatusNode{
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("a", chrome, false)}},
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("b", firefox, false)}},
+				{Availability: StatusUp, MaxSessions: 1, Slots: []StatusSlot{slot("c", chrome, false)}},
+			},
+			caps: map[string]interface{}{"browserName": "chrome"},
+			want: []string{"a", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := StatusValue{Nodes: tt.nodes}
+
+			var got []string
+			for _, sl := range s.FreeSlots(tt.caps) {
+				got = append(got, sl.ID.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
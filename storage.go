@@ -0,0 +1,128 @@
+package wdc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+//
+// TYPES
+//
+
+// Storage provides access to a web storage (localStorage or sessionStorage) of the current page origin.
+//
+// https://html.spec.whatwg.org/multipage/webstorage.html
+type Storage struct {
+	c    *Client
+	name string
+}
+
+// ErrorNoSuchStorageKey is returned when a key doesn't exist in a web storage.
+var ErrorNoSuchStorageKey = errors.New("no such storage key")
+
+//
+// METHODS
+//
+
+// LocalStorage returns accessors of the localStorage of the current page origin.
+func (c *Client) LocalStorage() Storage {
+	return Storage{c: c, name: "localStorage"}
+}
+
+// SessionStorage returns accessors of the sessionStorage of the current page origin.
+func (c *Client) SessionStorage() Storage {
+	return Storage{c: c, name: "sessionStorage"}
+}
+
+// Get returns a value of the key k. If the key doesn't exist, ErrorNoSuchStorageKey is returned.
+func (s Storage) Get(ctx context.Context, k string) (string, error) {
+	if k == "" {
+		return "", errors.New("storage key is empty")
+	}
+
+	v, err := ExecuteScript[*string](ctx, s.c, "return window[arguments[0]].getItem(arguments[1])", s.name, k)
+	if err != nil {
+		return "", err
+	}
+	if v == nil {
+		return "", ErrorNoSuchStorageKey
+	}
+
+	return *v, nil
+}
+
+// Set sets a value v of the key k.
+func (s Storage) Set(ctx context.Context, k, v string) error {
+	if k == "" {
+		return errors.New("storage key is empty")
+	}
+
+	_, err := s.c.PageScript(ctx, "window[arguments[0]].setItem(arguments[1], arguments[2])", []interface{}{s.name, k, v})
+	return err
+}
+
+// Remove removes the key k. Removing a missing key is not an error.
+func (s Storage) Remove(ctx context.Context, k string) error {
+	if k == "" {
+		return errors.New("storage key is empty")
+	}
+
+	_, err := s.c.PageScript(ctx, "window[arguments[0]].removeItem(arguments[1])", []interface{}{s.name, k})
+	return err
+}
+
+// Keys returns all keys of the storage.
+func (s Storage) Keys(ctx context.Context) ([]string, error) {
+	return ExecuteScript[[]string](ctx, s.c, "var s = window[arguments[0]], keys = []; for (var i = 0; i < s.length; i++) keys.push(s.key(i)); return keys", s.name)
+}
+
+// Clear removes all keys of the storage.
+func (s Storage) Clear(ctx context.Context) error {
+	_, err := s.c.PageScript(ctx, "window[arguments[0]].clear()", []interface{}{s.name})
+	return err
+}
+
+// All returns all keys of the storage with their values.
+func (s Storage) All(ctx context.Context) (map[string]string, error) {
+	m, err := ExecuteScript[map[string]string](ctx, s.c, "var s = window[arguments[0]], all = {}; for (var i = 0; i < s.length; i++) all[s.key(i)] = s.getItem(s.key(i)); return all", s.name)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		m = map[string]string{}
+	}
+
+	return m, nil
+}
+
+// SetJSON sets a JSON encoded value v of the key k.
+func (s Storage) SetJSON(ctx context.Context, k string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return s.Set(ctx, k, string(b))
+}
+
+//
+// FUNCTIONS
+//
+
+// StorageGetJSON returns a JSON encoded value of the key k of storage s decoded into type T.
+func StorageGetJSON[T any](ctx context.Context, s Storage, k string) (T, error) {
+	var v T
+
+	raw, err := s.Get(ctx, k)
+	if err != nil {
+		return v, err
+	}
+
+	err = json.Unmarshal([]byte(raw), &v)
+	if err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
@@ -4,21 +4,40 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 )
 
 //
-// REQUESTS
+// TYPES
 //
 
+// Timeout represents the timeouts associated with a session. Nil fields are left unchanged when timeouts are set.
+//
+// https://www.w3.org/TR/webdriver/#timeouts
 type Timeout struct {
-	Implicit time.Duration
-	PageLoad time.Duration
-	Script   time.Duration
+	// Implicit is the amount of time the driver should wait when searching for elements.
+	Implicit *time.Duration
+	// PageLoad is the amount of time to interrupt a navigation attempt.
+	PageLoad *time.Duration
+	// Script is the amount of time to interrupt a script that is being evaluated, TimeoutInfinite disables it.
+	Script *time.Duration
+}
+
+// TimeoutInfinite is a script timeout value which means the script is never interrupted.
+const TimeoutInfinite time.Duration = -1
+
+// TimeoutValue returns a pointer to timeout d to be used in Timeout.
+func TimeoutValue(d time.Duration) *time.Duration {
+	return &d
 }
 
+//
+// REQUESTS
+//
+
 type timeoutRequest struct {
 	Implicit uint `json:"implicit"`
 }
@@ -37,9 +56,9 @@ type timeoutScriptRequest struct {
 
 type timeoutResponse struct {
 	Value struct {
-		Implicit uint `json:"implicit"`
-		PageLoad uint `json:"pageLoad"`
-		Script   uint `json:"script"`
+		Implicit *uint           `json:"implicit"`
+		PageLoad *uint           `json:"pageLoad"`
+		Script   json.RawMessage `json:"script"`
 	} `json:"value"`
 }
 
@@ -65,11 +84,95 @@ func (c *Client) Timeouts(ctx context.Context) (Timeout, error) {
 		return Timeout{}, err
 	}
 
-	return Timeout{
-		Implicit: time.Duration(res.Value.Implicit) * time.Millisecond,
-		PageLoad: time.Duration(res.Value.PageLoad) * time.Millisecond,
-		Script:   time.Duration(res.Value.Script) * time.Millisecond,
-	}, nil
+	t := Timeout{}
+
+	if v := res.Value.Implicit; v != nil {
+		t.Implicit = TimeoutValue(time.Duration(*v) * time.Millisecond)
+	}
+	if v := res.Value.PageLoad; v != nil {
+		t.PageLoad = TimeoutValue(time.Duration(*v) * time.Millisecond)
+	}
+	if v := res.Value.Script; v != nil {
+		// null script timeout means the script is never interrupted
+		var ms *uint
+		err = json.Unmarshal(v, &ms)
+		if err != nil {
+			return Timeout{}, err
+		}
+		t.Script = TimeoutValue(TimeoutInfinite)
+		if ms != nil {
+			t.Script = TimeoutValue(time.Duration(*ms) * time.Millisecond)
+		}
+	}
+
+	return t, nil
+}
+
+// SetTimeouts command is used to set the timeouts t associated with the current session in a single request.
+//
+// Only non-nil fields of t are changed. A script timeout set to TimeoutInfinite is sent as null.
+// https://www.w3.org/TR/webdriver/#set-timeouts
+func (c *Client) SetTimeouts(ctx context.Context, t Timeout) error {
+	r := map[string]interface{}{}
+
+	if t.Implicit != nil {
+		if *t.Implicit < 0 {
+			return errors.New("implicit timeout is negative")
+		}
+		r["implicit"] = uint(*t.Implicit / time.Millisecond)
+	}
+	if t.PageLoad != nil {
+		if *t.PageLoad < 0 {
+			return errors.New("page load timeout is negative")
+		}
+		r["pageLoad"] = uint(*t.PageLoad / time.Millisecond)
+	}
+	if t.Script != nil {
+		switch {
+		case *t.Script == TimeoutInfinite:
+			r["script"] = nil
+		case *t.Script < 0:
+			return errors.New("script timeout is negative")
+		default:
+			r["script"] = uint(*t.Script / time.Millisecond)
+		}
+	}
+
+	b := new(bytes.Buffer)
+	err := json.NewEncoder(b).Encode(r)
+	if err != nil {
+		return err
+	}
+
+	route := fmt.Sprintf("session/%s/timeouts", c.session.ID)
+
+	req, err := c.prepare(http.MethodPost, route, b)
+	if err != nil {
+		return err
+	}
+
+	return c.do(ctx, req, nil)
+}
+
+// WithTimeouts temporarily sets the timeouts t, runs fn and then restores the previous timeouts.
+//
+// The previous timeouts are restored even if fn returns an error.
+func (c *Client) WithTimeouts(ctx context.Context, t Timeout, fn func() error) (err error) {
+	prev, err := c.Timeouts(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = c.SetTimeouts(ctx, t)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		err = withRestore(err, c.SetTimeouts(ctx, prev))
+	}()
+
+	return fn()
 }
 
 // TimeoutElementFind command is used to set the amount of time d the driver should wait when searching for elements.
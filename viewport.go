@@ -0,0 +1,179 @@
+package wdc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+//
+// TYPES
+//
+
+// Viewport represents a named size of the page viewport (the inner size of a window without browser chrome).
+type Viewport struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// Common viewport presets for responsive testing.
+var (
+	ViewportPhone           = Viewport{Name: "phone", Width: 375, Height: 667}
+	ViewportPhoneLarge      = Viewport{Name: "phone-large", Width: 414, Height: 896}
+	ViewportTablet          = Viewport{Name: "tablet", Width: 768, Height: 1024}
+	ViewportTabletLandscape = Viewport{Name: "tablet-landscape", Width: 1024, Height: 768}
+	ViewportDesktop         = Viewport{Name: "desktop", Width: 1366, Height: 768}
+	ViewportDesktopLarge    = Viewport{Name: "desktop-large", Width: 1920, Height: 1080}
+)
+
+// ViewportPresets lists all viewport presets from the smallest to the largest breakpoint.
+var ViewportPresets = []Viewport{
+	ViewportPhone,
+	ViewportPhoneLarge,
+	ViewportTablet,
+	ViewportTabletLandscape,
+	ViewportDesktop,
+	ViewportDesktopLarge,
+}
+
+// ViewportResult is an outcome of running a callback within a viewport.
+type ViewportResult struct {
+	Viewport Viewport
+	// Actual is the viewport size which was really set by the browser.
+	Actual Viewport
+	// Screenshot is a base64 encoded screenshot taken after the callback, if requested.
+	Screenshot string
+	// Err is an error returned by the callback.
+	Err error
+}
+
+//
+// RESPONSES
+//
+
+type viewportMetrics struct {
+	InnerWidth  int `json:"innerWidth"`
+	InnerHeight int `json:"innerHeight"`
+	OuterWidth  int `json:"outerWidth"`
+	OuterHeight int `json:"outerHeight"`
+}
+
+const viewportMetricsScript = `return {
+	innerWidth: window.innerWidth,
+	innerHeight: window.innerHeight,
+	outerWidth: window.outerWidth,
+	outerHeight: window.outerHeight
+};`
+
+//
+// METHODS
+//
+
+// Viewport returns the current viewport size of the page.
+func (c *Client) Viewport(ctx context.Context) (Viewport, error) {
+	m, err := c.viewportMetrics(ctx)
+	if err != nil {
+		return Viewport{}, err
+	}
+
+	return Viewport{Width: m.InnerWidth, Height: m.InnerHeight}, nil
+}
+
+// ViewportSet sets the viewport size of the page to width w and height h exactly.
+//
+// The window is resized with compensation for the browser chrome which is measured via script. The resulting viewport size is returned,
+// it may differ from the requested one if the window can't be resized that much.
+func (c *Client) ViewportSet(ctx context.Context, w, h int) (Viewport, error) {
+	if w <= 0 {
+		return Viewport{}, errors.New("viewport width is empty")
+	}
+	if h <= 0 {
+		return Viewport{}, errors.New("viewport height is empty")
+	}
+
+	m, err := c.viewportMetrics(ctx)
+	if err != nil {
+		return Viewport{}, err
+	}
+
+	// the second attempt corrects chrome changes caused by the resize itself, e.g. appeared scrollbars
+	for i := 0; i < 2; i++ {
+		if m.InnerWidth == w && m.InnerHeight == h {
+			break
+		}
+
+		_, err = c.WindowResize(ctx, "", w+m.OuterWidth-m.InnerWidth, h+m.OuterHeight-m.InnerHeight)
+		if err != nil {
+			return Viewport{}, err
+		}
+
+		m, err = c.viewportMetrics(ctx)
+		if err != nil {
+			return Viewport{}, err
+		}
+	}
+
+	return Viewport{Width: m.InnerWidth, Height: m.InnerHeight}, nil
+}
+
+// ViewportRun runs callback fn once per viewport of vs and collects per viewport results.
+//
+// Errors returned by fn are stored in the results and don't stop the run. If screenshot is true, a page screenshot is taken after every callback.
+// The original window size and position are restored afterwards.
+func (c *Client) ViewportRun(ctx context.Context, vs []Viewport, fn func(v Viewport) error, screenshot bool) (res []ViewportResult, err error) {
+	if fn == nil {
+		return nil, errors.New("callback is empty")
+	}
+
+	orig, err := c.WindowSize(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_, rerr := c.WindowSetRect(ctx, "", Rect{Width: &orig.Width, Height: &orig.Height, X: &orig.X, Y: &orig.Y})
+		err = withRestore(err, rerr)
+	}()
+
+	res = make([]ViewportResult, 0, len(vs))
+
+	for _, v := range vs {
+		actual, err := c.ViewportSet(ctx, v.Width, v.Height)
+		if err != nil {
+			return res, fmt.Errorf("viewport %s: %w", v.Name, err)
+		}
+		actual.Name = v.Name
+
+		r := ViewportResult{Viewport: v, Actual: actual, Err: fn(v)}
+
+		if screenshot {
+			r.Screenshot, err = c.PageScreenshot(ctx)
+			if err != nil {
+				return res, fmt.Errorf("viewport %s: %w", v.Name, err)
+			}
+		}
+
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+// viewportMetrics measures inner and outer sizes of the current window via script.
+func (c *Client) viewportMetrics(ctx context.Context) (viewportMetrics, error) {
+	s, err := c.PageScript(ctx, viewportMetricsScript, nil)
+	if err != nil {
+		return viewportMetrics{}, err
+	}
+
+	m := viewportMetrics{}
+
+	err = json.Unmarshal([]byte(s), &m)
+	if err != nil {
+		return viewportMetrics{}, err
+	}
+
+	return m, nil
+}
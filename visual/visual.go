@@ -0,0 +1,262 @@
+// Package visual provides screenshot based visual regression comparison against stored baseline images.
+package visual
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+
+	"github.com/codedius/wdc"
+)
+
+//
+// TYPES
+//
+
+// Options describes how images are compared.
+type Options struct {
+	// Tolerance is the maximum difference of any color channel (0 - 255) for pixels to be considered equal.
+	Tolerance uint8
+	// AntiAliasing enables ignoring of differences caused by anti-aliasing, i.e. pixels which match one of their neighbours in the other image.
+	AntiAliasing bool
+	// AntiAliasingTolerance is the maximum color channel difference for a pixel to match a neighbour when AntiAliasing is enabled.
+	AntiAliasingTolerance uint8
+	// MaxDiffRatio is the ratio (0 - 1) of different pixels which is still considered a match.
+	MaxDiffRatio float64
+	// Ignore lists regions of the image in pixels which are not compared, see Region.
+	Ignore []image.Rectangle
+	// DiffPath is a path of a PNG file the diff image is written to on mismatch. If empty, no file is written.
+	DiffPath string
+	// Update enables the update baselines mode: a baseline is overwritten with the actual image and the comparison always matches.
+	Update bool
+}
+
+// Result is an outcome of an image comparison.
+type Result struct {
+	// Match reports whether images are considered equal.
+	Match bool
+	// Updated reports whether the baseline was written instead of compared.
+	Updated bool
+	// DiffPixels is a number of different pixels.
+	DiffPixels int
+	// TotalPixels is a number of compared pixels.
+	TotalPixels int
+	// Diff is an image highlighting different pixels in red and anti-aliased pixels in yellow. It's nil on match.
+	Diff image.Image
+}
+
+//
+// ERRORS
+//
+
+// ErrorNoBaseline is returned when a baseline image doesn't exist and the update mode is off.
+var ErrorNoBaseline = errors.New("baseline image does not exist")
+
+//
+// FUNCTIONS
+//
+
+// Region converts element rect r to a region of a screenshot in pixels.
+//
+// Rect r is taken relative to the origin, which is the document scroll position for viewport screenshots or the rect of an element for element screenshots.
+// Only X and Y of origin are used. Scale is the device pixel ratio of the screenshot.
+func Region(r, origin wdc.ElementRect, scale float64) image.Rectangle {
+	return image.Rect(
+		int(math.Floor((r.X-origin.X)*scale)),
+		int(math.Floor((r.Y-origin.Y)*scale)),
+		int(math.Ceil((r.X-origin.X+r.Width)*scale)),
+		int(math.Ceil((r.Y-origin.Y+r.Height)*scale)),
+	)
+}
+
+// Compare compares an actual image with a baseline image with options o. Files are neither read nor written.
+//
+// Images of different sizes never match, all pixels outside of the common area are considered different.
+func Compare(actual, baseline image.Image, o Options) Result {
+	ab, bb := actual.Bounds(), baseline.Bounds()
+	w, h := maxInt(ab.Dx(), bb.Dx()), maxInt(ab.Dy(), bb.Dy())
+
+	diff := image.NewRGBA(image.Rect(0, 0, w, h))
+	res := Result{}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if ignored(x, y, o.Ignore) {
+				continue
+			}
+			res.TotalPixels++
+
+			if x >= ab.Dx() || y >= ab.Dy() || x >= bb.Dx() || y >= bb.Dy() {
+				res.DiffPixels++
+				diff.Set(x, y, color.RGBA{R: 255, A: 255})
+				continue
+			}
+
+			ac := actual.At(ab.Min.X+x, ab.Min.Y+y)
+			bc := baseline.At(bb.Min.X+x, bb.Min.Y+y)
+
+			if equal(ac, bc, o.Tolerance) {
+				diff.Set(x, y, faded(bc))
+				continue
+			}
+
+			if o.AntiAliasing && (neighbour(ac, baseline, bb.Min.X+x, bb.Min.Y+y, o.AntiAliasingTolerance) ||
+				neighbour(bc, actual, ab.Min.X+x, ab.Min.Y+y, o.AntiAliasingTolerance)) {
+				diff.Set(x, y, color.RGBA{R: 255, G: 255, A: 255})
+				continue
+			}
+
+			res.DiffPixels++
+			diff.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+
+	res.Match = ab.Dx() == bb.Dx() && ab.Dy() == bb.Dy()
+	if res.Match && res.DiffPixels > 0 {
+		res.Match = res.TotalPixels > 0 && float64(res.DiffPixels)/float64(res.TotalPixels) <= o.MaxDiffRatio
+	}
+	if !res.Match {
+		res.Diff = diff
+	}
+
+	return res
+}
+
+// CompareFile compares an actual image with a baseline PNG image with path p and options o.
+//
+// In the update mode the baseline is written and the comparison matches. Otherwise a missing baseline yields ErrorNoBaseline.
+// On mismatch the diff image is written to o.DiffPath if it's set.
+func CompareFile(actual image.Image, p string, o Options) (Result, error) {
+	if p == "" {
+		return Result{}, errors.New("baseline path is empty")
+	}
+
+	if o.Update {
+		err := Save(actual, p)
+		if err != nil {
+			return Result{}, err
+		}
+
+		b := actual.Bounds()
+
+		return Result{Match: true, Updated: true, TotalPixels: b.Dx() * b.Dy()}, nil
+	}
+
+	baseline, err := Load(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return Result{}, ErrorNoBaseline
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	res := Compare(actual, baseline, o)
+
+	if !res.Match && o.DiffPath != "" {
+		err = Save(res.Diff, o.DiffPath)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// Load reads a PNG image from a file with path p.
+func Load(p string) (image.Image, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return png.Decode(f)
+}
+
+// Save writes image img as PNG to a file with path p creating missing directories.
+func Save(img image.Image, p string) error {
+	err := os.MkdirAll(filepath.Dir(p), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return err
+	}
+
+	err = png.Encode(f, img)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+//
+// UTILS
+//
+
+// ignored reports whether pixel x, y belongs to any of regions rs.
+func ignored(x, y int, rs []image.Rectangle) bool {
+	p := image.Pt(x, y)
+	for _, r := range rs {
+		if p.In(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal reports whether every color channel of a and b differs by at most t.
+func equal(a, b color.Color, t uint8) bool {
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+
+	return channel(ar, br) <= t && channel(ag, bg) <= t && channel(ab, bb) <= t && channel(aa, ba) <= t
+}
+
+// channel returns the difference of 16-bit color channels a and b scaled to 8 bits.
+func channel(a, b uint32) uint8 {
+	a, b = a>>8, b>>8
+	if a > b {
+		return uint8(a - b)
+	}
+	return uint8(b - a)
+}
+
+// neighbour reports whether color c equals any pixel of img around x, y with tolerance t.
+func neighbour(c color.Color, img image.Image, x, y int, t uint8) bool {
+	b := img.Bounds()
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+			p := image.Pt(x+dx, y+dy)
+			if p.In(b) && equal(c, img.At(p.X, p.Y), t) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// maxInt returns the larger of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// faded returns a light gray version of color c used as a background of the diff image.
+func faded(c color.Color) color.Color {
+	g := color.GrayModel.Convert(c).(color.Gray)
+	return color.RGBA{R: 192 + g.Y/4, G: 192 + g.Y/4, B: 192 + g.Y/4, A: 255}
+}
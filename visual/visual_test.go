@@ -0,0 +1,208 @@
+package visual
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	gray := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+
+	// fill returns a 10x10 image of color gray with pixels ps set to color c.
+	fill := func(c color.Color, ps ...image.Point) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+		for y := 0; y < 10; y++ {
+			for x := 0; x < 10; x++ {
+				img.Set(x, y, gray)
+			}
+		}
+		for _, p := range ps {
+			img.Set(p.X, p.Y, c)
+		}
+		return img
+	}
+
+	// shifted returns image img moved to origin (x, y).
+	shifted := func(img image.Image, x, y int) image.Image {
+		rgba := img.(*image.RGBA)
+		out := *rgba
+		out.Rect = rgba.Rect.Add(image.Pt(x, y))
+		return &out
+	}
+
+	near := color.RGBA{R: 133, G: 124, B: 128, A: 255}
+	red := color.RGBA{R: 255, A: 255}
+
+	tests := []struct {
+		name     string
+		actual   image.Image
+		baseline image.Image
+		o        Options
+		match    bool
+		diff     int
+		total    int
+	}{
+		{
+			name:     "identical",
+			actual:   fill(red),
+			baseline: fill(red),
+			match:    true,
+			total:    100,
+		},
+		{
+			name:     "difference without tolerance",
+			actual:   fill(near, image.Pt(1, 1)),
+			baseline: fill(gray),
+			diff:     1,
+			total:    100,
+		},
+		{
+			name:     "difference within tolerance",
+			actual:   fill(near, image.Pt(1, 1)),
+			baseline: fill(gray),
+			o:        Options{Tolerance: 5},
+			match:    true,
+			total:    100,
+		},
+		{
+			name:     "difference above tolerance",
+			actual:   fill(near, image.Pt(1, 1)),
+			baseline: fill(gray),
+			o:        Options{Tolerance: 4},
+			diff:     1,
+			total:    100,
+		},
+		{
+			name:     "difference in ignored region",
+			actual:   fill(red, image.Pt(2, 2), image.Pt(3, 3)),
+			baseline: fill(gray),
+			o:        Options{Ignore: []image.Rectangle{image.Rect(2, 2, 4, 4)}},
+			match:    true,
+			total:    96,
+		},
+		{
+			name:     "difference partially ignored",
+			actual:   fill(red, image.Pt(2, 2), image.Pt(5, 5)),
+			baseline: fill(gray),
+			o:        Options{Ignore: []image.Rectangle{image.Rect(2, 2, 4, 4)}},
+			diff:     1,
+			total:    96,
+		},
+		{
+			name:     "difference within max ratio",
+			actual:   fill(red, image.Pt(0, 0), image.Pt(9, 9)),
+			baseline: fill(gray),
+			o:        Options{MaxDiffRatio: 0.02},
+			match:    true,
+			diff:     2,
+			total:    100,
+		},
+		{
+			name:     "difference above max ratio",
+			actual:   fill(red, image.Pt(0, 0), image.Pt(9, 9)),
+			baseline: fill(gray),
+			o:        Options{MaxDiffRatio: 0.01},
+			diff:     2,
+			total:    100,
+		},
+		{
+			name:     "anti-aliased pixel",
+			actual:   fill(red, image.Pt(4, 4)),
+			baseline: fill(red, image.Pt(5, 4)),
+			o:        Options{AntiAliasing: true},
+			match:    true,
+			total:    100,
+		},
+		{
+			name:     "different width",
+			actual:   image.NewRGBA(image.Rect(0, 0, 12, 10)),
+			baseline: image.NewRGBA(image.Rect(0, 0, 10, 10)),
+			diff:     20,
+			total:    120,
+		},
+		{
+			name:     "different size with max ratio",
+			actual:   image.NewRGBA(image.Rect(0, 0, 10, 10)),
+			baseline: image.NewRGBA(image.Rect(0, 0, 10, 11)),
+			o:        Options{MaxDiffRatio: 1},
+			diff:     10,
+			total:    110,
+		},
+		{
+			name:     "different origin",
+			actual:   shifted(fill(red, image.Pt(1, 1)), 5, 7),
+			baseline: fill(red, image.Pt(1, 1)),
+			match:    true,
+			total:    100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Compare(tt.actual, tt.baseline, tt.o)
+
+			if res.Match != tt.match {
+				t.Errorf("got match %v, want %v", res.Match, tt.match)
+			}
+			if res.DiffPixels != tt.diff {
+				t.Errorf("got %d different pixels, want %d", res.DiffPixels, tt.diff)
+			}
+			if res.TotalPixels != tt.total {
+				t.Errorf("got %d total pixels, want %d", res.TotalPixels, tt.total)
+			}
+			if (res.Diff == nil) != tt.match {
+				t.Errorf("got diff image %v, want it only on mismatch", res.Diff != nil)
+			}
+		})
+	}
+}
+
+func TestCompareFile(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, "baseline", "page.png")
+	diffPath := filepath.Join(dir, "diff", "page.png")
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+
+	_, err := CompareFile(img, p, Options{})
+	if !errors.Is(err, ErrorNoBaseline) {
+		t.Fatalf("got error %v, want %v", err, ErrorNoBaseline)
+	}
+
+	res, err := CompareFile(img, p, Options{Update: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Match || !res.Updated || res.TotalPixels != 16 {
+		t.Fatalf("got %+v, want updated match of 16 pixels", res)
+	}
+
+	res, err = CompareFile(img, p, Options{DiffPath: diffPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.Match || res.Updated {
+		t.Fatalf("got %+v, want match", res)
+	}
+
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+
+	res, err = CompareFile(img, p, Options{DiffPath: diffPath})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Match || res.DiffPixels != 1 {
+		t.Fatalf("got %+v, want mismatch of 1 pixel", res)
+	}
+
+	diff, err := Load(diffPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.Bounds() != img.Bounds() {
+		t.Errorf("got diff bounds %v, want %v", diff.Bounds(), img.Bounds())
+	}
+}
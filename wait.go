@@ -0,0 +1,139 @@
+package wdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+//
+// TYPES
+//
+
+// Document ready states in order of page loading.
+//
+// https://html.spec.whatwg.org/multipage/dom.html#current-document-readiness
+const (
+	ReadyStateLoading     = "loading"
+	ReadyStateInteractive = "interactive"
+	ReadyStateComplete    = "complete"
+)
+
+var readyStates = map[string]int{
+	ReadyStateLoading:     0,
+	ReadyStateInteractive: 1,
+	ReadyStateComplete:    2,
+}
+
+type networkState struct {
+	Inflight int     `json:"inflight"`
+	Idle     float64 `json:"idle"`
+}
+
+// networkInstallScript installs fetch and XMLHttpRequest instrumentation once per document.
+const networkInstallScript = `var n = window.__wdcNetwork;
+if (!n) {
+	n = window.__wdcNetwork = {inflight: 0, last: Date.now()};
+	var start = function() { n.inflight++; n.last = Date.now(); };
+	var done = function() { n.inflight = Math.max(0, n.inflight - 1); n.last = Date.now(); };
+	if (window.fetch) {
+		var fetch = window.fetch;
+		window.fetch = function() {
+			start();
+			return fetch.apply(this, arguments).then(function(r) { done(); return r; }, function(e) { done(); throw e; });
+		};
+	}
+	var send = XMLHttpRequest.prototype.send;
+	XMLHttpRequest.prototype.send = function() {
+		start();
+		this.addEventListener('loadend', done);
+		return send.apply(this, arguments);
+	};
+}`
+
+// networkScript installs the instrumentation if it's missing and returns the count of requests in flight
+// together with the time in milliseconds since the last request started or finished.
+const networkScript = networkInstallScript + `
+return {inflight: n.inflight, idle: Date.now() - n.last};`
+
+//
+// METHODS
+//
+
+// NetworkTrack enables tracking of fetch and XMLHttpRequest requests of the current page for WaitForNetworkIdle.
+//
+// The instrumentation is injected into the current document and injected again after NavigateTo, NavigateBack, NavigateForward and PageRefresh,
+// so requests started by an action are tracked from the start. Requests started before the injection, e.g. during the page load, are not tracked.
+func (c *Client) NetworkTrack(ctx context.Context) error {
+	c.mu.Lock()
+	c.network = true
+	c.mu.Unlock()
+
+	return c.networkInject(ctx)
+}
+
+// NetworkTrackStop disables injection of the network instrumentation after navigation. Instrumentation already injected into the current document stays active.
+func (c *Client) NetworkTrackStop() {
+	c.mu.Lock()
+	c.network = false
+	c.mu.Unlock()
+}
+
+// WaitForReadyState sets interval i and amount of time t the driver should wait for the document to reach ready state s or a later one.
+func (c *Client) WaitForReadyState(ctx context.Context, s string, i time.Duration, t time.Duration) error {
+	want, ok := readyStates[s]
+	if !ok {
+		return fmt.Errorf("unknown ready state %q", s)
+	}
+
+	start := time.Now()
+
+	for {
+		cur, err := c.PageScript(ctx, "return document.readyState", nil)
+		if err != nil {
+			return err
+		}
+		if got, ok := readyStates[cur]; ok && got >= want {
+			return nil
+		}
+
+		if elapsed := time.Since(start); elapsed > t {
+			return fmt.Errorf("timeout after %v", elapsed)
+		}
+		time.Sleep(i)
+	}
+}
+
+// WaitForNetworkIdle sets interval i and amount of time t the driver should wait until the page has no fetch or XMLHttpRequest requests in flight for quiet period q.
+//
+// Requests are tracked by instrumentation injected into the current document. Unless NetworkTrack was called before, it's injected on the first check,
+// so requests started before that, e.g. by the action being waited for, are not tracked.
+func (c *Client) WaitForNetworkIdle(ctx context.Context, q time.Duration, i time.Duration, t time.Duration) error {
+	if q < 0 {
+		return errors.New("quiet period is negative")
+	}
+
+	start := time.Now()
+
+	for {
+		s, err := ExecuteScript[networkState](ctx, c, networkScript)
+		if err != nil {
+			return err
+		}
+		if s.Inflight == 0 && time.Duration(s.Idle)*time.Millisecond >= q {
+			return nil
+		}
+
+		if elapsed := time.Since(start); elapsed > t {
+			return fmt.Errorf("timeout after %v", elapsed)
+		}
+		time.Sleep(i)
+	}
+}
+
+// networkInject injects the network instrumentation into the current document.
+func (c *Client) networkInject(ctx context.Context) error {
+	_, err := c.PageScript(ctx, networkInstallScript, nil)
+	return err
+}
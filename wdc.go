@@ -2,6 +2,7 @@
 package wdc
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"errors"
@@ -11,7 +12,11 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
 )
 
 //
@@ -24,6 +29,8 @@ type Session struct {
 	ID string
 	// URL of a web driver server
 	URL string
+	// Protocol of a web driver server. If empty, it's detected on demand.
+	Protocol Protocol
 }
 
 // Client for a server API.
@@ -31,6 +38,17 @@ type Client struct {
 	session *Session
 	client  *http.Client
 	url     *url.URL
+
+	mu       sync.Mutex
+	protocol Protocol
+	// frames is a path from the top-level browsing context to the active frame.
+	frames []interface{}
+	// console enables injection of the console hooks after navigation.
+	console bool
+	// consoleErr is an error of the last injection of the console hooks.
+	consoleErr error
+	// network enables injection of the network instrumentation after navigation.
+	network bool
 }
 
 //
@@ -56,9 +74,10 @@ func New(s *Session) (*Client, error) {
 	}
 
 	c := &Client{
-		session: s,
-		client:  httpc,
-		url:     u,
+		session:  s,
+		client:   httpc,
+		url:      u,
+		protocol: s.Protocol,
 	}
 
 	return c, nil
@@ -81,10 +100,28 @@ func (c *Client) prepare(method string, path string, body io.Reader) (*http.Requ
 	return req, nil
 }
 
-// do sends a server request and returns server response.
+// do sends a server request and decodes server response into v.
 //
 // The provided ctx must be non-nil. If it is canceled or time out, ctx.Err() will be returned.
 func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error {
+	return c.send(ctx, req, func(body io.Reader) error {
+		if v == nil {
+			return nil
+		}
+
+		err := json.NewDecoder(body).Decode(v)
+		if err == io.EOF {
+			return nil // ignore EOF errors caused by empty response body
+		}
+
+		return err
+	})
+}
+
+// send sends a server request and passes successful server response body to fn.
+//
+// The provided ctx must be non-nil. If it is canceled or time out, ctx.Err() will be returned.
+func (c *Client) send(ctx context.Context, req *http.Request, fn func(body io.Reader) error) error {
 	req = req.WithContext(ctx)
 
 	resp, err := c.client.Do(req)
@@ -104,16 +141,7 @@ func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) error
 		return err
 	}
 
-	if v == nil {
-		return nil
-	}
-
-	err = json.NewDecoder(resp.Body).Decode(v)
-	if err == io.EOF {
-		return nil // ignore EOF errors caused by empty response body
-	}
-
-	return err
+	return fn(resp.Body)
 }
 
 // check checks the server response for errors.
@@ -132,7 +160,11 @@ func check(r *http.Response) error {
 	if data != nil {
 		err = json.Unmarshal(data, errResp)
 		if err != nil {
-			return err
+			// some servers respond to unsupported commands with a plain text or HTML body
+			if r.StatusCode == http.StatusNotFound || r.StatusCode == http.StatusMethodNotAllowed {
+				return fmt.Errorf("%w: %s", ErrorUnknownCommand, r.Status)
+			}
+			return fmt.Errorf("unexpected response %s: %w", r.Status, err)
 		}
 	}
 
@@ -187,6 +219,11 @@ func (s *stringValue) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// rawValue is a response from the server which value is decoded later.
+type rawValue struct {
+	Value json.RawMessage `json:"value"`
+}
+
 // boolValue is a simplified bool response from the server.
 type boolValue struct {
 	Value bool `json:"value"`
@@ -311,6 +348,207 @@ func (e *ErrorResponse) Error() string {
 // UTILS
 //
 
+// stringValueReader returns a reader of the string value field of a server response body r without buffering the whole string.
+//
+// It's intended for large base64 encoded values.
+func stringValueReader(r io.Reader) (io.Reader, error) {
+	dec := json.NewDecoder(r)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('{') {
+		return nil, errors.New("response is not an object")
+	}
+
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok != "value" {
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		br := bufio.NewReader(io.MultiReader(dec.Buffered(), r))
+
+		for {
+			b, err := br.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if b == ':' || b == ' ' || b == '\t' || b == '\r' || b == '\n' {
+				continue
+			}
+			if b != '"' {
+				return nil, errors.New("response value is not a string")
+			}
+			break
+		}
+
+		return &jsonStringReader{r: br}, nil
+	}
+
+	return nil, errors.New("response value is empty")
+}
+
+// jsonStringReader reads JSON string content up to the closing quote, decoding escape sequences.
+type jsonStringReader struct {
+	r       *bufio.Reader
+	pending []byte
+	done    bool
+}
+
+func (s *jsonStringReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(s.pending) > 0 {
+			c := copy(p[n:], s.pending)
+			s.pending = s.pending[c:]
+			n += c
+			continue
+		}
+		if s.done {
+			break
+		}
+
+		b, err := s.readByte()
+		if err != nil {
+			return n, err
+		}
+
+		switch b {
+		case '"':
+			s.done = true
+			continue
+		case '\\':
+			err = s.unescape()
+			if err != nil {
+				return n, err
+			}
+			continue
+		}
+
+		p[n] = b
+		n++
+	}
+
+	if n == 0 && s.done {
+		return 0, io.EOF
+	}
+
+	return n, nil
+}
+
+// unescape decodes an escape sequence following a backslash into pending bytes.
+func (s *jsonStringReader) unescape() error {
+	b, err := s.readByte()
+	if err != nil {
+		return err
+	}
+
+	switch b {
+	case '"', '\\', '/':
+	case 'b':
+		b = '\b'
+	case 'f':
+		b = '\f'
+	case 'n':
+		b = '\n'
+	case 'r':
+		b = '\r'
+	case 't':
+		b = '\t'
+	case 'u':
+		r, err := s.readHex()
+		if err != nil {
+			return err
+		}
+		if utf16.IsSurrogate(r) {
+			r = s.readLowSurrogate(r)
+		}
+		s.pending = utf8.AppendRune(s.pending[:0], r)
+		return nil
+	default:
+		return fmt.Errorf("invalid escape sequence \\%c", b)
+	}
+
+	s.pending = append(s.pending[:0], b)
+
+	return nil
+}
+
+// readLowSurrogate reads the low surrogate of a pair started with high surrogate hi.
+// If it's missing, the replacement character is returned and the following bytes are left unread.
+func (s *jsonStringReader) readLowSurrogate(hi rune) rune {
+	next, err := s.r.Peek(6)
+	if err != nil || next[0] != '\\' || next[1] != 'u' {
+		return utf8.RuneError
+	}
+
+	lo, err := parseHex(next[2:])
+	if err != nil {
+		return utf8.RuneError
+	}
+
+	r := utf16.DecodeRune(hi, lo)
+	if r != utf8.RuneError {
+		_, _ = s.r.Discard(6)
+	}
+
+	return r
+}
+
+// readHex reads 4 hex digits of a \u escape sequence.
+func (s *jsonStringReader) readHex() (rune, error) {
+	var h [4]byte
+	for i := range h {
+		b, err := s.readByte()
+		if err != nil {
+			return 0, err
+		}
+		h[i] = b
+	}
+
+	return parseHex(h[:])
+}
+
+// readByte reads the next byte treating the end of input as an unterminated string.
+func (s *jsonStringReader) readByte() (byte, error) {
+	b, err := s.r.ReadByte()
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return b, err
+}
+
+// parseHex parses 4 hex digits h into a rune.
+func parseHex(h []byte) (rune, error) {
+	v, err := strconv.ParseUint(string(h), 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid escape sequence \\u%s", h)
+	}
+	return rune(v), nil
+}
+
+// withRestore combines error err of a scoped action with error rerr of restoring the previous context.
+func withRestore(err, rerr error) error {
+	if rerr == nil {
+		return err
+	}
+	if err == nil {
+		return fmt.Errorf("restoring context: %w", rerr)
+	}
+	return fmt.Errorf("%w (restoring context: %v)", err, rerr)
+}
+
 // safeclose is a convenient function for defer closing io.Closer c types.
 func safeclose(c io.Closer) {
 	err := c.Close()
@@ -0,0 +1,154 @@
+package wdc
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestStringValueReader(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+		err  bool
+	}{
+		{
+			name: "value first",
+			body: `{"value":"aGVsbG8="}`,
+			want: "aGVsbG8=",
+		},
+		{
+			name: "value not first",
+			body: `{"sessionId":"s1","status":0,"other":{"value":"x","list":[1,"2"]},"value" : "YWJj"}`,
+			want: "YWJj",
+		},
+		{
+			name: "whitespace",
+			body: "{\n\t\"value\"\r\n:\t\"YWJj\"\n}",
+			want: "YWJj",
+		},
+		{
+			name: "escaped slash",
+			body: `{"value":"a\/b\/c"}`,
+			want: "a/b/c",
+		},
+		{
+			name: "escaped padding",
+			body: `{"value":"aGk\u003d\u003D"}`,
+			want: "aGk==",
+		},
+		{
+			name: "line breaks",
+			body: `{"value":"YW\r\nJj\n"}`,
+			want: "YW\r\nJj\n",
+		},
+		{
+			name: "simple escapes",
+			body: `{"value":"\"\\\b\f\t"}`,
+			want: "\"\\\b\f\t",
+		},
+		{
+			name: "unicode",
+			body: `{"value":"\u00e9\u20ac\ud83d\ude00"}`,
+			want: "é€😀",
+		},
+		{
+			name: "lone surrogate",
+			body: `{"value":"\ud83dx"}`,
+			want: "\uFFFDx",
+		},
+		{
+			name: "empty",
+			body: `{"value":""}`,
+			want: "",
+		},
+		{
+			name: "truncated",
+			body: `{"value":"aGVsbG`,
+			err:  true,
+		},
+		{
+			name: "truncated escape",
+			body: `{"value":"aGk\u00`,
+			err:  true,
+		},
+		{
+			name: "invalid escape",
+			body: `{"value":"a\x"}`,
+			err:  true,
+		},
+		{
+			name: "invalid unicode escape",
+			body: `{"value":"a\u00zz"}`,
+			err:  true,
+		},
+		{
+			name: "not a string",
+			body: `{"value":{"a":1}}`,
+			err:  true,
+		},
+		{
+			name: "missing",
+			body: `{"status":0}`,
+			err:  true,
+		},
+		{
+			name: "not an object",
+			body: `["value"]`,
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readStringValue(tt.body)
+			if tt.err {
+				if err == nil {
+					t.Fatalf("got %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStringValueReaderSmallReads(t *testing.T) {
+	r, err := stringValueReader(strings.NewReader(`{"value":"a\u00e9\ud83d\ude00b"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []byte
+	p := make([]byte, 1)
+	for {
+		n, err := r.Read(p)
+		got = append(got, p[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if want := "aé😀b"; string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func readStringValue(body string) (string, error) {
+	r, err := stringValueReader(strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	b, err := io.ReadAll(r)
+	return string(b), err
+}
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 )
 
 //
@@ -21,15 +23,53 @@ type WindowSize struct {
 	Y      int `json:"y"`
 }
 
+// Rect represents a requested size and position of a window. Nil fields are left unchanged.
+type Rect struct {
+	Height *int `json:"height,omitempty"`
+	Width  *int `json:"width,omitempty"`
+	X      *int `json:"x,omitempty"`
+	Y      *int `json:"y,omitempty"`
+}
+
+// RectSize returns a Rect which changes only the width w and height h of a window.
+func RectSize(w, h int) Rect {
+	return Rect{Width: &w, Height: &h}
+}
+
+// RectPosition returns a Rect which changes only the position (coord X - x and coord Y - y) of a window.
+func RectPosition(x, y int) Rect {
+	return Rect{X: &x, Y: &y}
+}
+
 // WindowID represents an id of a window.
 type WindowID string
 
+// WindowType is a hint of what kind of top-level browsing context should be created.
+//
+// https://www.w3.org/TR/webdriver/#new-window
+type WindowType string
+
+const (
+	WindowTypeTab    WindowType = "tab"
+	WindowTypeWindow WindowType = "window"
+)
+
+// Window represents a newly created top-level browsing context.
+type Window struct {
+	ID   WindowID   `json:"handle"`
+	Type WindowType `json:"type"`
+}
+
 //
 // REQUESTS
 //
 
 type windowRequest struct {
-	Name string `json:"name"`
+	Name WindowID `json:"name"`
+}
+
+type windowNewRequest struct {
+	Type WindowType `json:"type,omitempty"`
 }
 
 //
@@ -40,6 +80,30 @@ type windowsResponse struct {
 	Value []WindowID `json:"value"`
 }
 
+type windowNewResponse struct {
+	Value Window `json:"value"`
+}
+
+// UnmarshalJSON decodes the W3C {handle, type} value and falls back to a plain handle string returned by some servers.
+func (w *windowNewResponse) UnmarshalJSON(bytes []byte) error {
+	type priv struct {
+		Value json.RawMessage `json:"value"`
+	}
+	p := priv{}
+	err := json.Unmarshal(bytes, &p)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	if err := json.Unmarshal(p.Value, &id); err == nil {
+		w.Value = Window{ID: WindowID(id)}
+		return nil
+	}
+
+	return json.Unmarshal(p.Value, &w.Value)
+}
+
 type windowSizeResponse struct {
 	Value WindowSize `json:"value"`
 }
@@ -90,25 +154,34 @@ func (c *Client) WindowIDs(ctx context.Context) ([]WindowID, error) {
 	return res.Value, nil
 }
 
-// WindowNew command is used to create new window.
+// WindowNew command is used to create a new top-level browsing context of type t.
 //
+// Type t is only a hint, the created window type is reported back. If t is empty, the remote end decides what to create.
 // https://www.w3.org/TR/webdriver/#new-window
-func (c *Client) WindowNew(ctx context.Context) (WindowID, error) {
+func (c *Client) WindowNew(ctx context.Context, t WindowType) (Window, error) {
+	r := &windowNewRequest{Type: t}
+
+	b := new(bytes.Buffer)
+	err := json.NewEncoder(b).Encode(r)
+	if err != nil {
+		return Window{}, err
+	}
+
 	route := fmt.Sprintf("session/%s/window/new", c.session.ID)
 
-	req, err := c.prepare(http.MethodPost, route, nil)
+	req, err := c.prepare(http.MethodPost, route, b)
 	if err != nil {
-		return "", err
+		return Window{}, err
 	}
 
-	res := new(stringValue)
+	res := new(windowNewResponse)
 
 	err = c.do(ctx, req, res)
 	if err != nil {
-		return "", err
+		return Window{}, err
 	}
 
-	return WindowID(res.Value), nil
+	return res.Value, nil
 }
 
 // WindowClose command is used to close a window.
@@ -122,13 +195,20 @@ func (c *Client) WindowClose(ctx context.Context) error {
 		return err
 	}
 
-	return c.do(ctx, req, nil)
+	err = c.do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	c.setFrames(nil)
+
+	return nil
 }
 
-// WindowClose command is used to close a window with ID wid.
+// WindowSwitch command is used to switch to a window with ID wid.
 //
 // https://www.w3.org/TR/webdriver/#switch-to-window
-func (c *Client) WindowSwitch(ctx context.Context, wid string) error {
+func (c *Client) WindowSwitch(ctx context.Context, wid WindowID) error {
 	if wid == "" {
 		return errors.New("window ID is empty")
 	}
@@ -148,154 +228,277 @@ func (c *Client) WindowSwitch(ctx context.Context, wid string) error {
 		return err
 	}
 
-	return c.do(ctx, req, nil)
-}
-
-// WindowSize command is used to get the size of the specified window.
-//
-// https://www.w3.org/TR/webdriver/#get-window-rect
-func (c *Client) WindowSize(ctx context.Context) (WindowSize, error) {
-	route := fmt.Sprintf("session/%s/window/rect", c.session.ID)
-
-	req, err := c.prepare(http.MethodGet, route, nil)
+	err = c.do(ctx, req, nil)
 	if err != nil {
-		return WindowSize{}, err
+		return err
 	}
 
-	res := new(windowSizeResponse)
+	c.setFrames(nil)
 
-	err = c.do(ctx, req, res)
-	if err != nil {
-		return WindowSize{}, err
-	}
-
-	return res.Value, nil
+	return nil
 }
 
-// WindowResize command is used to change the size (width - w, height - h, coord X - x and coord Y - y) of the specified window.
+// WindowSwitchBy switches to the first window whose title and URL satisfy predicate pred and returns its ID.
 //
-// https://www.w3.org/TR/webdriver/#set-window-rect
-func (c *Client) WindowResize(ctx context.Context, w, h, x, y int) error {
-	if w == 0 {
-		return errors.New("window width is empty")
+// Windows are checked in the order returned by WindowIDs. If no window matches, ErrorNoSuchWindow is returned.
+// If no window matches or an error occurs, the previously active window and frame are restored.
+func (c *Client) WindowSwitchBy(ctx context.Context, pred func(title, url string) bool) (WindowID, error) {
+	if pred == nil {
+		return "", errors.New("predicate is empty")
 	}
-	if h == 0 {
-		return errors.New("window height is empty")
+
+	prev, err := c.WindowID(ctx)
+	if err != nil && !errors.Is(err, ErrorNoSuchWindow) {
+		return "", err
 	}
 
-	r := &WindowSize{Width: w, Height: h, X: x, Y: y}
+	frames := c.framePath()
 
-	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
+	ids, err := c.WindowIDs(ctx)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	route := fmt.Sprintf("session/%s/window/rect", c.session.ID)
+	id, err := c.windowMatch(ctx, ids, pred)
+	if err == nil {
+		return id, nil
+	}
 
-	req, err := c.prepare(http.MethodPost, route, b)
-	if err != nil {
-		return err
+	if prev != "" {
+		rerr := c.WindowSwitch(ctx, prev)
+		if rerr == nil {
+			rerr = c.restoreFrames(ctx, frames)
+		}
+		err = withRestore(err, rerr)
 	}
 
-	return c.do(ctx, req, nil)
+	return "", err
 }
 
-// WindowMaximize command is used to maximize the specified window with ID wid.
-//
-// https://www.w3.org/TR/webdriver/#maximize-window
-func (c *Client) WindowMaximize(ctx context.Context, wid string) (WindowSize, error) {
-	if wid == "" {
-		return WindowSize{}, errors.New("window ID is empty")
+// windowMatch switches to windows ids one by one until the title and URL of a window satisfy predicate pred.
+// Windows closed during the search, before or after the switch, are skipped.
+func (c *Client) windowMatch(ctx context.Context, ids []WindowID, pred func(title, url string) bool) (WindowID, error) {
+	for _, id := range ids {
+		err := c.WindowSwitch(ctx, id)
+		if errors.Is(err, ErrorNoSuchWindow) {
+			continue // window has been closed in the meantime
+		}
+		if err != nil {
+			return "", err
+		}
+
+		title, err := c.PageTitle(ctx)
+		if errors.Is(err, ErrorNoSuchWindow) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		url, err := c.PageURL(ctx)
+		if errors.Is(err, ErrorNoSuchWindow) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if pred(title, url) {
+			return id, nil
+		}
 	}
 
-	route := fmt.Sprintf("session/%s/window/maximize", c.session.ID)
-
-	r := &windowRequest{Name: wid}
+	return "", ErrorNoSuchWindow
+}
 
-	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
-	if err != nil {
-		return WindowSize{}, err
+// ExpectNewWindow runs action fn which is expected to open a new window and returns the ID of that window.
+//
+// Window handles are snapshotted before fn is run, then polled with interval i for amount of time t until a new handle appears.
+// If switchTo is true, the new window becomes active.
+func (c *Client) ExpectNewWindow(ctx context.Context, fn func() error, i time.Duration, t time.Duration, switchTo bool) (WindowID, error) {
+	if fn == nil {
+		return "", errors.New("action is empty")
 	}
 
-	req, err := c.prepare(http.MethodPost, route, b)
+	ids, err := c.WindowIDs(ctx)
 	if err != nil {
-		return WindowSize{}, err
+		return "", err
 	}
 
-	res := new(windowSizeResponse)
+	known := make(map[WindowID]bool, len(ids))
+	for _, id := range ids {
+		known[id] = true
+	}
 
-	err = c.do(ctx, req, res)
+	err = fn()
 	if err != nil {
-		return WindowSize{}, err
+		return "", err
 	}
 
-	return res.Value, nil
+	start := time.Now()
+
+	for {
+		ids, err = c.WindowIDs(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		for _, id := range ids {
+			if known[id] {
+				continue
+			}
+			if switchTo {
+				err = c.WindowSwitch(ctx, id)
+				if err != nil {
+					return "", err
+				}
+			}
+			return id, nil
+		}
+
+		if elapsed := time.Since(start); elapsed > t {
+			return "", fmt.Errorf("timeout after %v", elapsed)
+		}
+		time.Sleep(i)
+	}
 }
 
-// WindowMinimize command is used to minimize the specified window with ID wid.
+// WithinWindow switches to a window with ID wid, runs fn and then restores the previously active window and frame.
 //
-// https://www.w3.org/TR/webdriver/#minimize-window
-func (c *Client) WindowMinimize(ctx context.Context, wid string) (WindowSize, error) {
-	if wid == "" {
-		return WindowSize{}, errors.New("window ID is empty")
+// The previous window is restored even if fn returns an error or switches to another window itself.
+func (c *Client) WithinWindow(ctx context.Context, wid WindowID, fn func() error) (err error) {
+	prev, err := c.WindowID(ctx)
+	if err != nil {
+		return err
 	}
 
-	route := fmt.Sprintf("session/%s/window/minimize", c.session.ID)
-
-	r := &windowRequest{Name: wid}
+	frames := c.framePath()
 
-	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
+	err = c.WindowSwitch(ctx, wid)
 	if err != nil {
-		return WindowSize{}, err
+		return err
 	}
 
-	req, err := c.prepare(http.MethodPost, route, b)
-	if err != nil {
-		return WindowSize{}, err
-	}
+	defer func() {
+		rerr := c.WindowSwitch(ctx, prev)
+		if rerr == nil {
+			rerr = c.restoreFrames(ctx, frames)
+		}
+		err = withRestore(err, rerr)
+	}()
 
-	res := new(windowSizeResponse)
+	return fn()
+}
 
-	err = c.do(ctx, req, res)
-	if err != nil {
-		return WindowSize{}, err
+// WindowSize command is used to get the size and position of a window with ID wid. If wid is empty, the current window is used.
+//
+// https://www.w3.org/TR/webdriver/#get-window-rect
+func (c *Client) WindowSize(ctx context.Context, wid WindowID) (WindowSize, error) {
+	return c.windowRect(ctx, wid, http.MethodGet, "rect", nil)
+}
+
+// WindowSetRect command is used to change the size and position of a window with ID wid to rect r. If wid is empty, the current window is used.
+//
+// Only the fields set in r are changed, so a window can be moved without resizing it and vice versa. The resulting size and position is returned.
+// https://www.w3.org/TR/webdriver/#set-window-rect
+func (c *Client) WindowSetRect(ctx context.Context, wid WindowID, r Rect) (WindowSize, error) {
+	if r.Width != nil && *r.Width < 0 {
+		return WindowSize{}, errors.New("window width is negative")
+	}
+	if r.Height != nil && *r.Height < 0 {
+		return WindowSize{}, errors.New("window height is negative")
 	}
 
-	return res.Value, nil
+	return c.windowRect(ctx, wid, http.MethodPost, "rect", r)
 }
 
-// WindowFullscreen command is used to fullscreen the specified window with ID wid.
+// WindowResize command is used to change the size (width - w, height - h) of a window with ID wid without moving it. If wid is empty, the current window is used.
 //
-// https://www.w3.org/TR/webdriver/#fullscreen-window
-func (c *Client) WindowFullscreen(ctx context.Context, wid string) (WindowSize, error) {
-	if wid == "" {
-		return WindowSize{}, errors.New("window ID is empty")
+// https://www.w3.org/TR/webdriver/#set-window-rect
+func (c *Client) WindowResize(ctx context.Context, wid WindowID, w, h int) (WindowSize, error) {
+	if w == 0 {
+		return WindowSize{}, errors.New("window width is empty")
+	}
+	if h == 0 {
+		return WindowSize{}, errors.New("window height is empty")
 	}
 
-	route := fmt.Sprintf("session/%s/window/fullscreen", c.session.ID)
+	return c.WindowSetRect(ctx, wid, RectSize(w, h))
+}
 
-	r := &windowRequest{Name: wid}
+// WindowMove command is used to change the position (coord X - x and coord Y - y) of a window with ID wid without resizing it. If wid is empty, the current window is used.
+//
+// https://www.w3.org/TR/webdriver/#set-window-rect
+func (c *Client) WindowMove(ctx context.Context, wid WindowID, x, y int) (WindowSize, error) {
+	return c.WindowSetRect(ctx, wid, RectPosition(x, y))
+}
 
-	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(r)
-	if err != nil {
-		return WindowSize{}, err
-	}
+// WindowMaximize command is used to maximize a window with ID wid. If wid is empty, the current window is used.
+//
+// https://www.w3.org/TR/webdriver/#maximize-window
+func (c *Client) WindowMaximize(ctx context.Context, wid WindowID) (WindowSize, error) {
+	return c.windowRect(ctx, wid, http.MethodPost, "maximize", struct{}{})
+}
 
-	req, err := c.prepare(http.MethodPost, route, b)
-	if err != nil {
-		return WindowSize{}, err
-	}
+// WindowMinimize command is used to minimize a window with ID wid. If wid is empty, the current window is used.
+//
+// https://www.w3.org/TR/webdriver/#minimize-window
+func (c *Client) WindowMinimize(ctx context.Context, wid WindowID) (WindowSize, error) {
+	return c.windowRect(ctx, wid, http.MethodPost, "minimize", struct{}{})
+}
 
-	res := new(windowSizeResponse)
+// WindowFullscreen command is used to fullscreen a window with ID wid. If wid is empty, the current window is used.
+//
+// https://www.w3.org/TR/webdriver/#fullscreen-window
+func (c *Client) WindowFullscreen(ctx context.Context, wid WindowID) (WindowSize, error) {
+	return c.windowRect(ctx, wid, http.MethodPost, "fullscreen", struct{}{})
+}
 
-	err = c.do(ctx, req, res)
+// windowRect sends a window rect command to a window with ID wid and returns the resulting size and position.
+//
+// If wid is not empty, the window is switched to and the previously active window is restored afterwards.
+func (c *Client) windowRect(ctx context.Context, wid WindowID, method, command string, v interface{}) (WindowSize, error) {
+	var ws WindowSize
+
+	fn := func() error {
+		var body io.Reader
+		if v != nil {
+			b := new(bytes.Buffer)
+			err := json.NewEncoder(b).Encode(v)
+			if err != nil {
+				return err
+			}
+			body = b
+		}
+
+		route := fmt.Sprintf("session/%s/window/%s", c.session.ID, command)
+
+		req, err := c.prepare(method, route, body)
+		if err != nil {
+			return err
+		}
+
+		res := new(windowSizeResponse)
+
+		err = c.do(ctx, req, res)
+		if err != nil {
+			return err
+		}
+
+		ws = res.Value
+
+		return nil
+	}
+
+	var err error
+	if wid == "" {
+		err = fn()
+	} else {
+		err = c.WithinWindow(ctx, wid, fn)
+	}
 	if err != nil {
 		return WindowSize{}, err
 	}
 
-	return res.Value, nil
+	return ws, nil
 }